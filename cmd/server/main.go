@@ -1,8 +1,14 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"github.com/joho/godotenv"
 
@@ -32,19 +38,67 @@ func main() {
 
 	if err := gormDB.AutoMigrate(
 		&models.User{},
+		&models.ProductCategory{},
+		&models.Product{},
+		&models.ProductModule{},
+		&models.SigningKey{},
+		&models.Tag{},
 		&models.Release{},
 		&models.ReleaseModule{},
+		&models.SecurityAdvisory{},
 		&models.ChangelogEntry{},
 		&models.FirmwareLink{},
+		&models.LinkVerification{},
+		&models.ReleaseStatusTransition{},
+		&models.ReleaseApprovalRequest{},
+		&models.ReleaseApproval{},
+		&models.ReleaseRevision{},
+		&models.Classification{},
+		&models.ReleaseTranslation{},
+		&models.ChangelogEntryTranslation{},
+		&models.KnownIssue{},
 	); err != nil {
 		log.Fatal(err)
 	}
+	if err := db.BackfillProducts(gormDB); err != nil {
+		log.Fatal(err)
+	}
+	if err := db.DropGlobalVersionIndex(gormDB); err != nil {
+		log.Fatal(err)
+	}
+	if err := db.CanonicalizeVersions(gormDB); err != nil {
+		log.Fatal(err)
+	}
+	if err := db.SeedClassifications(gormDB); err != nil {
+		log.Fatal(err)
+	}
+	if err := db.BackfillLinkKinds(gormDB); err != nil {
+		log.Fatal(err)
+	}
 
 	// opcional
 	handlers.SeedAdmin(gormDB)
 
-	r := router.Setup(gormDB, cfg.JWTSecret)
-	if err := r.Run(":" + cfg.Port); err != nil {
+	// SIGINT/SIGTERM param as rotinas em segundo plano e o servidor
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	r, jobs := router.Setup(gormDB, cfg.JWTSecret)
+	jobs(ctx)
+
+	srv := &http.Server{Addr: ":" + cfg.Port, Handler: r}
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Printf("encerramento: %v", err)
+		}
+	}()
+	if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
 		log.Fatal(err)
 	}
+	<-closed // espera as requisições em andamento
 }
@@ -0,0 +1,154 @@
+// internal/cvss/cvss.go
+package cvss
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// Severidades qualitativas da especificação CVSS v3.x.
+const (
+	SeverityNone     = "none"
+	SeverityLow      = "low"
+	SeverityMedium   = "medium"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+)
+
+// pesos das métricas base (CVSS v3.1, seção 7.4)
+var weights = map[string]map[string]float64{
+	"AV": {"N": 0.85, "A": 0.62, "L": 0.55, "P": 0.2},
+	"AC": {"L": 0.77, "H": 0.44},
+	"PR": {"N": 0.85, "L": 0.62, "H": 0.27},
+	"UI": {"N": 0.85, "R": 0.62},
+	"S":  {"U": 0, "C": 0},
+	"C":  {"H": 0.56, "L": 0.22, "N": 0},
+	"I":  {"H": 0.56, "L": 0.22, "N": 0},
+	"A":  {"H": 0.56, "L": 0.22, "N": 0},
+}
+
+// métricas base, na ordem da especificação
+var baseOrder = []string{"AV", "AC", "PR", "UI", "S", "C", "I", "A"}
+
+// métricas temporais e ambientais: aceitas no vetor, mas fora do score base
+var otherMetrics = map[string]bool{
+	"E": true, "RL": true, "RC": true,
+	"CR": true, "IR": true, "AR": true,
+	"MAV": true, "MAC": true, "MPR": true, "MUI": true, "MS": true,
+	"MC": true, "MI": true, "MA": true,
+}
+
+// Vector é um vetor CVSS v3.0/v3.1 já validado.
+type Vector struct {
+	Version string            // "3.0" ou "3.1"
+	Base    map[string]string // AV, AC, PR, UI, S, C, I, A
+}
+
+// Parse interpreta um vetor como "CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H".
+// Todas as métricas base são obrigatórias.
+func Parse(s string) (Vector, error) {
+	parts := strings.Split(strings.TrimSpace(s), "/")
+	v := Vector{Base: map[string]string{}}
+	switch parts[0] {
+	case "CVSS:3.0":
+		v.Version = "3.0"
+	case "CVSS:3.1":
+		v.Version = "3.1"
+	default:
+		return v, fmt.Errorf("vetor CVSS inválido %q: use o prefixo CVSS:3.1/ ou CVSS:3.0/", s)
+	}
+	seen := map[string]bool{}
+	for _, p := range parts[1:] {
+		key, val, ok := strings.Cut(p, ":")
+		if !ok || key == "" || val == "" {
+			return v, fmt.Errorf("vetor CVSS inválido: métrica %q", p)
+		}
+		if seen[key] {
+			return v, fmt.Errorf("vetor CVSS inválido: métrica %s repetida", key)
+		}
+		seen[key] = true
+		if w, ok := weights[key]; ok {
+			if _, ok := w[val]; !ok {
+				return v, fmt.Errorf("vetor CVSS inválido: valor %q para %s", val, key)
+			}
+			v.Base[key] = val
+			continue
+		}
+		if !otherMetrics[key] {
+			return v, fmt.Errorf("vetor CVSS inválido: métrica desconhecida %s", key)
+		}
+	}
+	for _, key := range baseOrder {
+		if v.Base[key] == "" {
+			return v, fmt.Errorf("vetor CVSS incompleto: falta a métrica %s", key)
+		}
+	}
+	return v, nil
+}
+
+// BaseScore calcula o score base (0.0 a 10.0).
+func (v Vector) BaseScore() float64 {
+	changed := v.Base["S"] == "C"
+	pr := weights["PR"][v.Base["PR"]]
+	if changed {
+		// com escopo alterado, privilégios pesam menos
+		switch v.Base["PR"] {
+		case "L":
+			pr = 0.68
+		case "H":
+			pr = 0.5
+		}
+	}
+	iss := 1 - (1-weights["C"][v.Base["C"]])*(1-weights["I"][v.Base["I"]])*(1-weights["A"][v.Base["A"]])
+	var impact float64
+	if changed {
+		impact = 7.52*(iss-0.029) - 3.25*math.Pow(iss-0.02, 15)
+	} else {
+		impact = 6.42 * iss
+	}
+	if impact <= 0 {
+		return 0
+	}
+	exploitability := 8.22 * weights["AV"][v.Base["AV"]] * weights["AC"][v.Base["AC"]] * pr * weights["UI"][v.Base["UI"]]
+	if changed {
+		return roundUp(math.Min(1.08*(impact+exploitability), 10))
+	}
+	return roundUp(math.Min(impact+exploitability, 10))
+}
+
+// roundUp arredonda para cima na primeira casa decimal, como no apêndice A
+// da v3.1 (evita erros de ponto flutuante, ex.: 4.000001 -> 4.0).
+func roundUp(x float64) float64 {
+	n := int64(math.Round(x * 100000))
+	if n%10000 == 0 {
+		return float64(n) / 100000
+	}
+	return float64(n/10000+1) / 10
+}
+
+// Severity classifica um score na escala qualitativa.
+func Severity(score float64) string {
+	switch {
+	case score <= 0:
+		return SeverityNone
+	case score < 4:
+		return SeverityLow
+	case score < 7:
+		return SeverityMedium
+	case score < 9:
+		return SeverityHigh
+	default:
+		return SeverityCritical
+	}
+}
+
+// ValidSeverity informa se s é uma das severidades conhecidas.
+func ValidSeverity(s string) bool {
+	switch s {
+	case SeverityNone, SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical:
+		return true
+	default:
+		return false
+	}
+}
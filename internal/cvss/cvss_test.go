@@ -0,0 +1,52 @@
+package cvss_test
+
+import (
+	"testing"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/cvss"
+)
+
+func TestBaseScore(t *testing.T) {
+	cases := []struct {
+		vector string
+		score  float64
+		sev    string
+	}{
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", 9.8, cvss.SeverityCritical},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:C/C:H/I:H/A:H", 10.0, cvss.SeverityCritical},
+		{"CVSS:3.1/AV:L/AC:L/PR:L/UI:N/S:U/C:H/I:H/A:H", 7.8, cvss.SeverityHigh},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:R/S:C/C:L/I:L/A:N", 6.1, cvss.SeverityMedium},
+		{"CVSS:3.0/AV:P/AC:H/PR:H/UI:R/S:U/C:L/I:N/A:N", 1.6, cvss.SeverityLow},
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:N/I:N/A:N", 0, cvss.SeverityNone},
+		// métricas temporais são aceitas e não mudam o score base
+		{"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/E:P/RL:O", 9.8, cvss.SeverityCritical},
+	}
+	for _, tc := range cases {
+		v, err := cvss.Parse(tc.vector)
+		if err != nil {
+			t.Fatalf("%s: erro inesperado: %v", tc.vector, err)
+		}
+		if got := v.BaseScore(); got != tc.score {
+			t.Fatalf("%s: score = %.1f, esperava %.1f", tc.vector, got, tc.score)
+		}
+		if got := cvss.Severity(v.BaseScore()); got != tc.sev {
+			t.Fatalf("%s: severidade = %s, esperava %s", tc.vector, got, tc.sev)
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, s := range []string{
+		"",
+		"AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H", // sem prefixo
+		"CVSS:2.0/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",  // versão não suportada
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H",      // falta A
+		"CVSS:3.1/AV:X/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H",  // valor inválido
+		"CVSS:3.1/AV:N/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H", // repetida
+		"CVSS:3.1/AV:N/AC:L/PR:N/UI:N/S:U/C:H/I:H/A:H/ZZ:1",
+	} {
+		if _, err := cvss.Parse(s); err == nil {
+			t.Fatalf("%q: esperava erro", s)
+		}
+	}
+}
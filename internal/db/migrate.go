@@ -0,0 +1,255 @@
+// internal/db/migrate.go
+package db
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/version"
+)
+
+// UnassignedProduct recebe os releases antigos gravados sem produto; um
+// admin pode movê-los depois (PUT com productId).
+const UnassignedProduct = "Sem produto"
+
+// BackfillProducts converte os textos livres product_category/product_name
+// dos releases antigos em linhas do catálogo e preenche releases.product_id.
+// Nomes que só diferem em maiúsculas/espaços viram o mesmo produto; o
+// primeiro nome encontrado é usado como nome de exibição.
+// Releases sem nome de produto vão para o produto UnassignedProduct, para
+// continuarem editáveis (a edição exige produto).
+// Idempotente: só olha releases ainda sem product_id.
+func BackfillProducts(db *gorm.DB) error {
+	type pair struct {
+		ProductCategory string
+		ProductName     string
+	}
+	var pairs []pair
+	if err := db.Model(&models.Release{}).
+		Where("product_id IS NULL").
+		Distinct("COALESCE(product_category, '') AS product_category", "COALESCE(product_name, '') AS product_name").
+		Order("product_name ASC").
+		Find(&pairs).Error; err != nil {
+		return err
+	}
+	if len(pairs) == 0 {
+		return nil
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		for _, p := range pairs {
+			var catID *uint
+			if key := models.NormalizeKey(p.ProductCategory); key != "" {
+				cat := models.ProductCategory{Key: key}
+				if err := tx.Where("key = ?", key).
+					Attrs(models.ProductCategory{Name: strings.Join(strings.Fields(p.ProductCategory), " ")}).
+					FirstOrCreate(&cat).Error; err != nil {
+					return err
+				}
+				catID = &cat.ID
+			}
+
+			name := strings.Join(strings.Fields(p.ProductName), " ")
+			if name == "" {
+				name = UnassignedProduct
+			}
+			key := models.NormalizeKey(name)
+			prod := models.Product{Key: key}
+			if err := tx.Where("key = ?", key).
+				Attrs(models.Product{Name: name, CategoryID: catID}).
+				FirstOrCreate(&prod).Error; err != nil {
+				return err
+			}
+
+			catName := ""
+			if prod.CategoryID != nil {
+				var c models.ProductCategory
+				if err := tx.First(&c, *prod.CategoryID).Error; err != nil {
+					return err
+				}
+				catName = c.Name
+			}
+
+			res := tx.Model(&models.Release{}).
+				Where("product_id IS NULL AND COALESCE(product_category, '') = ? AND COALESCE(product_name, '') = ?", p.ProductCategory, p.ProductName).
+				Updates(map[string]any{"product_id": prod.ID, "product_name": prod.Name, "product_category": catName})
+			if res.Error != nil {
+				return res.Error
+			}
+			log.Printf("catálogo: %d release(s) %q/%q -> produto %d", res.RowsAffected, p.ProductCategory, p.ProductName, prod.ID)
+		}
+		return nil
+	})
+}
+
+// DropGlobalVersionIndex remove o índice único antigo em releases.version;
+// a unicidade agora é (product_id, version).
+func DropGlobalVersionIndex(db *gorm.DB) error {
+	const legacy = "idx_releases_version"
+	m := db.Migrator()
+	if !m.HasIndex(&models.Release{}, legacy) {
+		return nil
+	}
+	log.Printf("removendo índice legado %s", legacy)
+	return m.DropIndex(&models.Release{}, legacy)
+}
+
+// schemaMigration marca as migrações de dados que rodam uma vez só.
+type schemaMigration struct {
+	Name      string `gorm:"primaryKey;size:64"`
+	AppliedAt time.Time
+}
+
+func (schemaMigration) TableName() string { return "schema_migrations" }
+
+// once roda fn numa transação junto com a gravação da marca name; com a
+// marca já gravada, não faz nada.
+func once(db *gorm.DB, name string, fn func(tx *gorm.DB) error) error {
+	if err := db.AutoMigrate(&schemaMigration{}); err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		var n int64
+		if err := tx.Model(&schemaMigration{}).Where("name = ?", name).Count(&n).Error; err != nil {
+			return err
+		}
+		if n > 0 {
+			return nil
+		}
+		if err := fn(tx); err != nil {
+			return err
+		}
+		return tx.Create(&schemaMigration{Name: name, AppliedAt: time.Now()}).Error
+	})
+}
+
+// CanonicalizeVersions grava as versões dos releases antigos na forma
+// canônica (1.2 -> 1.2.0), a mesma usada pelo serviço, para a unicidade
+// por produto valer também para elas. Roda uma vez. Se dois releases do
+// mesmo produto (inclusive na lixeira) caírem na mesma forma canônica,
+// nada é gravado e o erro lista os conflitos: resolva-os (editando a
+// versão ou purgando o release) e reinicie.
+func CanonicalizeVersions(db *gorm.DB) error {
+	return once(db, "canonical_versions", func(tx *gorm.DB) error {
+		type row struct {
+			ID              uint
+			ProductID       *uint
+			Version         string
+			PreviousVersion string
+		}
+		var rows []row
+		if err := tx.Unscoped().Model(&models.Release{}).
+			Select("id", "product_id", "version", "previous_version").
+			Order("id ASC").
+			Find(&rows).Error; err != nil {
+			return err
+		}
+
+		type key struct {
+			product uint
+			version string
+		}
+		byKey := map[key][]uint{}
+		var keys []key
+		for _, r := range rows {
+			if r.ProductID == nil {
+				continue // NULL não conflita no índice único
+			}
+			k := key{*r.ProductID, version.Canonical(r.Version)}
+			if byKey[k] == nil {
+				keys = append(keys, k)
+			}
+			byKey[k] = append(byKey[k], r.ID)
+		}
+		var conflicts []string
+		for _, k := range keys {
+			if ids := byKey[k]; len(ids) > 1 {
+				conflicts = append(conflicts, fmt.Sprintf("produto %d, versão %s: releases %v", k.product, k.version, ids))
+			}
+		}
+		if len(conflicts) > 0 {
+			return fmt.Errorf("versões: releases com a mesma versão canônica no produto; resolva antes de iniciar:\n  %s",
+				strings.Join(conflicts, "\n  "))
+		}
+
+		n := 0
+		for _, r := range rows {
+			v, pv := version.Canonical(r.Version), version.Canonical(r.PreviousVersion)
+			if v == r.Version && pv == r.PreviousVersion {
+				continue
+			}
+			if err := tx.Unscoped().Model(&models.Release{}).Where("id = ?", r.ID).
+				UpdateColumns(map[string]any{"version": v, "previous_version": pv}).Error; err != nil {
+				return fmt.Errorf("versões: release %d: %q -> %q: %w", r.ID, r.Version, v, err)
+			}
+			n++
+		}
+		log.Printf("versões: %d release(s) gravado(s) na forma canônica", n)
+		return nil
+	})
+}
+
+// SeedClassifications cria o cadastro de classificações na primeira vez:
+// as quatro padrão e, depois delas, qualquer outro valor já gravado em
+// changelog_entries (para que releases antigos continuem editáveis).
+// Não faz nada se o cadastro já tiver linhas.
+func SeedClassifications(db *gorm.DB) error {
+	var n int64
+	if err := db.Model(&models.Classification{}).Count(&n).Error; err != nil {
+		return err
+	}
+	if n > 0 {
+		return nil
+	}
+
+	seed := models.DefaultClassifications()
+	known := map[string]bool{}
+	for _, c := range seed {
+		known[models.NormalizeKey(c.Key)] = true
+	}
+	var used []string
+	if err := db.Model(&models.ChangelogEntry{}).
+		Distinct("classification").
+		Order("classification ASC").
+		Pluck("classification", &used).Error; err != nil {
+		return err
+	}
+	order := seed[len(seed)-1].SortOrder
+	for _, k := range used {
+		k = strings.Join(strings.Fields(k), " ")
+		if k == "" || known[models.NormalizeKey(k)] {
+			continue
+		}
+		known[models.NormalizeKey(k)] = true
+		order += 10
+		seed = append(seed, models.Classification{Key: k, Labels: map[string]string{"pt-BR": k}, SortOrder: order})
+	}
+
+	log.Printf("criando %d classificação(ões) de changelog", len(seed))
+	return db.Create(&seed).Error
+}
+
+// BackfillLinkKinds preenche o tipo dos links antigos pela extensão do
+// arquivo (ver models.GuessLinkKind). Idempotente: só olha kind vazio.
+func BackfillLinkKinds(db *gorm.DB) error {
+	var links []models.FirmwareLink
+	if err := db.Select("id, url, description").Where("kind = ''").Find(&links).Error; err != nil {
+		return err
+	}
+	if len(links) == 0 {
+		return nil
+	}
+	log.Printf("classificando %d link(s) de firmware sem tipo", len(links))
+	for _, l := range links {
+		kind := models.GuessLinkKind(l.URL, l.Description)
+		if err := db.Model(&models.FirmwareLink{}).Where("id = ?", l.ID).UpdateColumn("kind", kind).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
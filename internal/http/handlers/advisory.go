@@ -0,0 +1,206 @@
+// internal/http/handlers/advisory.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/service"
+)
+
+type AdvisoryHandler struct {
+	Svc *service.AdvisoryService
+}
+
+type AdvisoryDTO struct {
+	ProductID    uint       `json:"productId" binding:"required"`
+	Identifier   string     `json:"identifier" binding:"required"` // CVE-2025-12345 ou ID interno
+	Title        string     `json:"title" binding:"required"`
+	Description  string     `json:"description"`
+	CVSSVector   string     `json:"cvssVector"`
+	CVSSScore    *float64   `json:"cvssScore"` // calculado do vetor se omitido
+	AffectedFrom string     `json:"affectedFrom"`
+	AffectedTo   string     `json:"affectedTo" binding:"required"`
+	FixedVersion string     `json:"fixedVersion"`
+	DisclosedAt  *time.Time `json:"disclosedAt"`
+}
+
+type AdvisoryPublic struct {
+	ID           uint                 `json:"id"`
+	Product      *ProductRef          `json:"product,omitempty"`
+	Identifier   string               `json:"identifier"`
+	Title        string               `json:"title"`
+	Description  string               `json:"description,omitempty"`
+	CVSSVector   string               `json:"cvssVector,omitempty"`
+	CVSSScore    float64              `json:"cvssScore"`
+	Severity     string               `json:"severity"`
+	AffectedFrom string               `json:"affectedFrom,omitempty"`
+	AffectedTo   string               `json:"affectedTo"`
+	FixedVersion string               `json:"fixedVersion,omitempty"`
+	DisclosedAt  *time.Time           `json:"disclosedAt,omitempty"`
+	Releases     []AdvisoryReleaseRef `json:"releases,omitempty"` // só no detalhe
+	UpdatedAt    time.Time            `json:"updatedAt"`
+}
+
+type ProductRef struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+// AdvisoryReleaseRef é um release com entrada ligada ao boletim.
+type AdvisoryReleaseRef struct {
+	ID          uint      `json:"id"`
+	Version     string    `json:"version"`
+	Status      string    `json:"status"`
+	ReleaseDate time.Time `json:"releaseDate"`
+}
+
+// AdvisorySummary acompanha a entrada de changelog que aponta para o boletim.
+type AdvisorySummary struct {
+	ID         uint    `json:"id"`
+	Identifier string  `json:"identifier"`
+	Title      string  `json:"title"`
+	CVSSScore  float64 `json:"cvssScore"`
+	Severity   string  `json:"severity"`
+}
+
+func toPublicAdvisory(a *models.SecurityAdvisory) AdvisoryPublic {
+	out := AdvisoryPublic{
+		ID: a.ID, Identifier: a.Identifier, Title: a.Title, Description: a.Description,
+		CVSSVector: a.CVSSVector, CVSSScore: a.CVSSScore, Severity: a.Severity,
+		AffectedFrom: a.AffectedFrom, AffectedTo: a.AffectedTo, FixedVersion: a.FixedVersion,
+		DisclosedAt: a.DisclosedAt, UpdatedAt: a.UpdatedAt,
+	}
+	if a.Product != nil {
+		out.Product = &ProductRef{ID: a.Product.ID, Name: a.Product.Name}
+	}
+	return out
+}
+
+func toAdvisorySummary(a *models.SecurityAdvisory) *AdvisorySummary {
+	if a == nil || a.ID == 0 {
+		return nil
+	}
+	return &AdvisorySummary{ID: a.ID, Identifier: a.Identifier, Title: a.Title, CVSSScore: a.CVSSScore, Severity: a.Severity}
+}
+
+func (in AdvisoryDTO) input() service.AdvisoryInput {
+	return service.AdvisoryInput{
+		ProductID: in.ProductID, Identifier: in.Identifier, Title: in.Title, Description: in.Description,
+		CVSSVector: in.CVSSVector, CVSSScore: in.CVSSScore,
+		AffectedFrom: in.AffectedFrom, AffectedTo: in.AffectedTo, FixedVersion: in.FixedVersion,
+		DisclosedAt: in.DisclosedAt,
+	}
+}
+
+func advisoryQuery(c *gin.Context) service.AdvisoryQuery {
+	return service.AdvisoryQuery{
+		Product:  c.Query("product"),
+		Severity: c.Query("severity"),
+		Version:  c.Query("version"),
+		Q:        c.Query("q"),
+	}
+}
+
+func respondAdvisoryError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrAdvisoryExists) || errors.Is(err, service.ErrAdvisoryInUse) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	respondCatalogError(c, err, "boletim de segurança")
+}
+
+func respondAdvisoryList(c *gin.Context, list []models.SecurityAdvisory) {
+	resp := make([]AdvisoryPublic, 0, len(list))
+	for i := range list {
+		resp = append(resp, toPublicAdvisory(&list[i]))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GET /api/advisories?product=&severity=&version=&q=
+// version filtra os boletins cuja faixa afetada contém a versão.
+func (h AdvisoryHandler) List(c *gin.Context) {
+	list, err := h.Svc.List(advisoryQuery(c))
+	if err != nil {
+		respondSvcError(c, err)
+		return
+	}
+	respondAdvisoryList(c, list)
+}
+
+// GET /api/products/:id/advisories  (":id" aceita ID ou nome do produto)
+func (h AdvisoryHandler) ListByProduct(c *gin.Context) {
+	list, err := h.Svc.ForProduct(c.Param("id"), advisoryQuery(c))
+	if err != nil {
+		respondCatalogError(c, err, "produto")
+		return
+	}
+	respondAdvisoryList(c, list)
+}
+
+// GET /api/advisories/:id  (inclui os releases que citam o boletim)
+func (h AdvisoryHandler) Get(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	a, rels, err := h.Svc.Get(id)
+	if err != nil {
+		respondAdvisoryError(c, err)
+		return
+	}
+	resp := toPublicAdvisory(a)
+	for _, r := range rels {
+		resp.Releases = append(resp.Releases, AdvisoryReleaseRef{ID: r.ID, Version: r.Version, Status: string(r.Status), ReleaseDate: r.ReleaseDate})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h AdvisoryHandler) Create(c *gin.Context) {
+	var in AdvisoryDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	out, err := h.Svc.Create(in.input())
+	if err != nil {
+		respondAdvisoryError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, toPublicAdvisory(out))
+}
+
+func (h AdvisoryHandler) Update(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	var in AdvisoryDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	out, err := h.Svc.Update(id, in.input())
+	if err != nil {
+		respondAdvisoryError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toPublicAdvisory(out))
+}
+
+func (h AdvisoryHandler) Delete(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.Svc.Delete(id); err != nil {
+		respondAdvisoryError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
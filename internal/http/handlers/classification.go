@@ -0,0 +1,105 @@
+// internal/http/handlers/classification.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/service"
+)
+
+type ClassificationHandler struct {
+	Svc *service.ClassificationService
+}
+
+type ClassificationDTO struct {
+	Key       string            `json:"key"` // só na criação; depois é fixa
+	Labels    map[string]string `json:"labels" binding:"required"`
+	Color     string            `json:"color"`
+	SortOrder int               `json:"sortOrder"`
+}
+
+type ClassificationPublic struct {
+	ID        uint              `json:"id"`
+	Key       string            `json:"key"`
+	Labels    map[string]string `json:"labels"`
+	Color     string            `json:"color,omitempty"`
+	SortOrder int               `json:"sortOrder"`
+}
+
+func toPublicClassification(c *models.Classification) ClassificationPublic {
+	return ClassificationPublic{ID: c.ID, Key: c.Key, Labels: c.Labels, Color: c.Color, SortOrder: c.SortOrder}
+}
+
+func (in ClassificationDTO) input() service.ClassificationInput {
+	return service.ClassificationInput{Key: in.Key, Labels: in.Labels, Color: in.Color, SortOrder: in.SortOrder}
+}
+
+func respondClassificationError(c *gin.Context, err error) {
+	if errors.Is(err, service.ErrClassificationExists) || errors.Is(err, service.ErrClassificationInUse) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	respondCatalogError(c, err, "classificação")
+}
+
+// GET /api/classifications  (ordem de exibição)
+func (h ClassificationHandler) List(c *gin.Context) {
+	list, err := h.Svc.List()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	resp := make([]ClassificationPublic, 0, len(list))
+	for i := range list {
+		resp = append(resp, toPublicClassification(&list[i]))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h ClassificationHandler) Create(c *gin.Context) {
+	var in ClassificationDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	out, err := h.Svc.Create(in.input())
+	if err != nil {
+		respondClassificationError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, toPublicClassification(out))
+}
+
+func (h ClassificationHandler) Update(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	var in ClassificationDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	out, err := h.Svc.Update(id, in.input())
+	if err != nil {
+		respondClassificationError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toPublicClassification(out))
+}
+
+func (h ClassificationHandler) Delete(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.Svc.Delete(id); err != nil {
+		respondClassificationError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,98 @@
+// internal/http/handlers/compatibility.go
+package handlers
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type CompatibilityCellPublic struct {
+	Version string `json:"version"`
+	Updated bool   `json:"updated"`
+}
+
+type CompatibilityRowPublic struct {
+	ReleaseID   uint      `json:"releaseId"`
+	Version     string    `json:"version"`
+	ReleaseDate time.Time `json:"releaseDate"`
+	Status      string    `json:"status"`
+	// nil quando o módulo não consta no release
+	Modules []*CompatibilityCellPublic `json:"modules"`
+}
+
+type CompatibilityResponse struct {
+	Product ProductPublic            `json:"product"`
+	Modules []string                 `json:"modules"`
+	Rows    []CompatibilityRowPublic `json:"rows"`
+}
+
+// GET /api/products/:id/compatibility[?format=csv]
+func (h ReleaseHandler) Compatibility(c *gin.Context) {
+	m, err := h.Svc.CompatibilityMatrix(c.Param("id"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "produto não encontrado"})
+			return
+		}
+		respondSvcError(c, err)
+		return
+	}
+
+	if c.Query("format") == "csv" {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="compatibilidade-%d.csv"`, m.Product.ID))
+		c.Status(http.StatusOK)
+
+		w := csv.NewWriter(c.Writer)
+		header := []string{"version", "releaseDate", "status"}
+		for _, mod := range m.Modules {
+			header = append(header, mod, mod+" updated")
+		}
+		_ = w.Write(header)
+		for _, r := range m.Rows {
+			rec := []string{r.Version, r.ReleaseDate.Format("2006-01-02"), string(r.Status)}
+			for _, cell := range r.Cells {
+				switch {
+				case !cell.Present:
+					rec = append(rec, "", "")
+				case cell.Updated:
+					rec = append(rec, cell.Version, "sim")
+				default:
+					rec = append(rec, cell.Version, "não")
+				}
+			}
+			_ = w.Write(rec)
+		}
+		w.Flush()
+		return
+	}
+
+	resp := CompatibilityResponse{
+		Product: toPublicProduct(m.Product),
+		Modules: m.Modules,
+		Rows:    make([]CompatibilityRowPublic, 0, len(m.Rows)),
+	}
+	if resp.Modules == nil {
+		resp.Modules = []string{}
+	}
+	for _, r := range m.Rows {
+		row := CompatibilityRowPublic{
+			ReleaseID: r.ReleaseID, Version: r.Version, ReleaseDate: r.ReleaseDate,
+			Status:  string(r.Status),
+			Modules: make([]*CompatibilityCellPublic, len(r.Cells)),
+		}
+		for i, cell := range r.Cells {
+			if cell.Present {
+				row.Modules[i] = &CompatibilityCellPublic{Version: cell.Version, Updated: cell.Updated}
+			}
+		}
+		resp.Rows = append(resp.Rows, row)
+	}
+	c.JSON(http.StatusOK, resp)
+}
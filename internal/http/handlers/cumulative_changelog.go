@@ -0,0 +1,58 @@
+// internal/http/handlers/cumulative_changelog.go
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CumulativeItemPublic struct {
+	ReleaseID   uint      `json:"releaseId"`
+	Version     string    `json:"version"`
+	ReleaseDate time.Time `json:"releaseDate"`
+	ItemOrder   int       `json:"itemOrder"`
+	Observation string    `json:"observation"`
+}
+
+type CumulativeGroupPublic struct {
+	Classification string                 `json:"classification"`
+	Items          []CumulativeItemPublic `json:"items"`
+}
+
+type CumulativeChangelogResponse struct {
+	Product  string                  `json:"product"`
+	From     string                  `json:"from"`
+	To       string                  `json:"to"`
+	Versions []string                `json:"versions"`
+	Groups   []CumulativeGroupPublic `json:"groups"`
+}
+
+// GET /api/releases/changelog?product=X&from=A&to=B  (A exclusiva, B inclusiva)
+func (h ReleaseHandler) CumulativeChangelog(c *gin.Context) {
+	product, from, to := c.Query("product"), c.Query("from"), c.Query("to")
+
+	cl, err := h.Svc.CumulativeChangelog(product, from, to)
+	if err != nil {
+		respondSvcError(c, err)
+		return
+	}
+
+	resp := CumulativeChangelogResponse{
+		Product: product, From: from, To: to,
+		Versions: cl.Versions,
+		Groups:   make([]CumulativeGroupPublic, 0, len(cl.Groups)),
+	}
+	for _, g := range cl.Groups {
+		items := make([]CumulativeItemPublic, 0, len(g.Items))
+		for _, it := range g.Items {
+			items = append(items, CumulativeItemPublic{
+				ReleaseID: it.ReleaseID, Version: it.Version, ReleaseDate: it.ReleaseDate,
+				ItemOrder: it.ItemOrder, Observation: it.Observation,
+			})
+		}
+		resp.Groups = append(resp.Groups, CumulativeGroupPublic{Classification: string(g.Classification), Items: items})
+	}
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,7 @@
+package handlers
+
+// acesso dos testes (pacote handlers_test) a funções internas
+var (
+	DiffReleases = diffReleases
+	MatchIfMatch = matchIfMatch
+)
@@ -0,0 +1,158 @@
+// internal/http/handlers/known_issue.go
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/service"
+)
+
+type KnownIssueHandler struct {
+	Svc *service.KnownIssueService
+}
+
+type KnownIssueDTO struct {
+	Title            string `json:"title" binding:"required"`
+	Description      string `json:"description"`
+	Severity         string `json:"severity"` // low|medium|high|critical (padrão: medium)
+	Workaround       string `json:"workaround"`
+	FixedInReleaseID *uint  `json:"fixedInReleaseId"` // release que corrige; null = em aberto
+}
+
+type KnownIssuePublic struct {
+	ID          uint             `json:"id"`
+	Title       string           `json:"title"`
+	Description string           `json:"description,omitempty"`
+	Severity    string           `json:"severity"`
+	Workaround  string           `json:"workaround,omitempty"`
+	FoundIn     *IssueReleaseRef `json:"foundIn,omitempty"`
+	FixedIn     *IssueReleaseRef `json:"fixedIn,omitempty"`
+	CreatedAt   time.Time        `json:"createdAt"`
+	UpdatedAt   time.Time        `json:"updatedAt"`
+}
+
+// IssueReleaseRef é o release em que o problema apareceu ou foi corrigido.
+type IssueReleaseRef struct {
+	ID          uint      `json:"id"`
+	Version     string    `json:"version"`
+	Status      string    `json:"status"`
+	ReleaseDate time.Time `json:"releaseDate"`
+}
+
+func toIssueReleaseRef(r *models.Release) *IssueReleaseRef {
+	if r == nil || r.ID == 0 {
+		return nil
+	}
+	return &IssueReleaseRef{ID: r.ID, Version: r.Version, Status: string(r.Status), ReleaseDate: r.ReleaseDate}
+}
+
+func toPublicKnownIssue(k *models.KnownIssue) KnownIssuePublic {
+	return KnownIssuePublic{
+		ID: k.ID, Title: k.Title, Description: k.Description,
+		Severity: string(k.Severity), Workaround: k.Workaround,
+		FoundIn: toIssueReleaseRef(k.Release), FixedIn: toIssueReleaseRef(k.FixedIn),
+		CreatedAt: k.CreatedAt, UpdatedAt: k.UpdatedAt,
+	}
+}
+
+func toPublicKnownIssues(list []models.KnownIssue) []KnownIssuePublic {
+	out := make([]KnownIssuePublic, 0, len(list))
+	for i := range list {
+		out = append(out, toPublicKnownIssue(&list[i]))
+	}
+	return out
+}
+
+func (in KnownIssueDTO) input() service.KnownIssueInput {
+	return service.KnownIssueInput{
+		Title: in.Title, Description: in.Description, Severity: in.Severity,
+		Workaround: in.Workaround, FixedInReleaseID: in.FixedInReleaseID,
+	}
+}
+
+// GET /api/releases/:id/known-issues  (em aberto nesta versão)
+func (h KnownIssueHandler) ListByRelease(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	list, err := h.Svc.ForRelease(id)
+	if err != nil {
+		respondSvcError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toPublicKnownIssues(list))
+}
+
+// GET /api/products/:id/known-issues  (todos, inclusive os corrigidos)
+func (h KnownIssueHandler) ListByProduct(c *gin.Context) {
+	list, err := h.Svc.ForProduct(c.Param("id"))
+	if err != nil {
+		respondCatalogError(c, err, "produto")
+		return
+	}
+	c.JSON(http.StatusOK, toPublicKnownIssues(list))
+}
+
+// POST /api/releases/:id/known-issues  (problema encontrado neste release)
+func (h KnownIssueHandler) Create(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	var in KnownIssueDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	out, err := h.Svc.Create(id, in.input())
+	if err != nil {
+		respondSvcError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, toPublicKnownIssue(out))
+}
+
+// PUT /api/known-issues/:id
+func (h KnownIssueHandler) Update(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	var in KnownIssueDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	out, err := h.Svc.Update(id, in.input())
+	if err != nil {
+		respondCatalogError(c, err, "problema conhecido")
+		return
+	}
+	c.JSON(http.StatusOK, toPublicKnownIssue(out))
+}
+
+// DELETE /api/known-issues/:id
+func (h KnownIssueHandler) Delete(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.Svc.Delete(id); err != nil {
+		respondCatalogError(c, err, "problema conhecido")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// openIssues devolve os problemas em aberto de cada release da lista.
+func (h ReleaseHandler) openIssues(list ...*models.Release) (map[uint][]models.KnownIssue, error) {
+	if h.Issues == nil {
+		return nil, nil
+	}
+	return h.Issues.Open(list...)
+}
@@ -0,0 +1,319 @@
+// internal/http/handlers/product.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/service"
+)
+
+type ProductHandler struct {
+	Svc *service.ProductService
+}
+
+/* ===== DTOs ===== */
+
+type ProductCategoryDTO struct {
+	Name string `json:"name" binding:"required"`
+}
+
+type ProductDTO struct {
+	Name       string `json:"name" binding:"required"`
+	CategoryID *uint  `json:"categoryId"`
+}
+
+type ProductCategoryPublic struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+}
+
+type ProductPublic struct {
+	ID       uint                   `json:"id"`
+	Name     string                 `json:"name"`
+	Category *ProductCategoryPublic `json:"category,omitempty"`
+}
+
+/* ===== Mappers ===== */
+
+func toPublicCategory(c *models.ProductCategory) *ProductCategoryPublic {
+	if c == nil {
+		return nil
+	}
+	return &ProductCategoryPublic{ID: c.ID, Name: c.Name}
+}
+
+func toPublicProduct(p *models.Product) ProductPublic {
+	return ProductPublic{ID: p.ID, Name: p.Name, Category: toPublicCategory(p.Category)}
+}
+
+// erros comuns de catálogo: 404, 400, 409 (nome duplicado ou ainda em uso)
+func respondCatalogError(c *gin.Context, err error, what string) {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": what + " não encontrado"})
+	case service.IsUniqueViolation(err):
+		c.JSON(http.StatusConflict, gin.H{"error": what + " já cadastrado"})
+	case service.IsForeignKeyViolation(err):
+		c.JSON(http.StatusConflict, gin.H{"error": what + " em uso"})
+	default:
+		respondSvcError(c, err)
+	}
+}
+
+func paramID(c *gin.Context, name string) (uint, bool) {
+	n, err := strconv.ParseUint(c.Param(name), 10, 64)
+	if err != nil || n == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "id inválido"})
+		return 0, false
+	}
+	return uint(n), true
+}
+
+/* ===== Categorias ===== */
+
+func (h ProductHandler) ListCategories(c *gin.Context) {
+	list, err := h.Svc.ListCategories()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	resp := make([]ProductCategoryPublic, 0, len(list))
+	for i := range list {
+		resp = append(resp, *toPublicCategory(&list[i]))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h ProductHandler) CreateCategory(c *gin.Context) {
+	var in ProductCategoryDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	out, err := h.Svc.CreateCategory(in.Name)
+	if err != nil {
+		respondCatalogError(c, err, "categoria")
+		return
+	}
+	c.JSON(http.StatusCreated, toPublicCategory(out))
+}
+
+func (h ProductHandler) UpdateCategory(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	var in ProductCategoryDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	out, err := h.Svc.UpdateCategory(id, in.Name)
+	if err != nil {
+		respondCatalogError(c, err, "categoria")
+		return
+	}
+	c.JSON(http.StatusOK, toPublicCategory(out))
+}
+
+func (h ProductHandler) DeleteCategory(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.Svc.DeleteCategory(id); err != nil {
+		respondCatalogError(c, err, "categoria")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+/* ===== Produtos ===== */
+
+// GET /api/products?category=<id>
+func (h ProductHandler) List(c *gin.Context) {
+	var catID uint
+	if v := c.Query("category"); v != "" {
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "category inválida"})
+			return
+		}
+		catID = uint(n)
+	}
+	list, err := h.Svc.List(catID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	resp := make([]ProductPublic, 0, len(list))
+	for i := range list {
+		resp = append(resp, toPublicProduct(&list[i]))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GET /api/products/:id  (aceita ID ou nome)
+func (h ProductHandler) Get(c *gin.Context) {
+	p, err := h.Svc.Resolve(c.Param("id"))
+	if err != nil {
+		respondCatalogError(c, err, "produto")
+		return
+	}
+	c.JSON(http.StatusOK, toPublicProduct(p))
+}
+
+func (h ProductHandler) Create(c *gin.Context) {
+	var in ProductDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	out, err := h.Svc.Create(in.Name, in.CategoryID)
+	if err != nil {
+		respondCatalogError(c, err, "produto")
+		return
+	}
+	c.JSON(http.StatusCreated, toPublicProduct(out))
+}
+
+func (h ProductHandler) Update(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	var in ProductDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	out, err := h.Svc.Update(id, in.Name, in.CategoryID)
+	if err != nil {
+		respondCatalogError(c, err, "produto")
+		return
+	}
+	c.JSON(http.StatusOK, toPublicProduct(out))
+}
+
+func (h ProductHandler) Delete(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.Svc.Delete(id); err != nil {
+		respondCatalogError(c, err, "produto")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+/* ===== Módulos de hardware ===== */
+
+type ProductModuleDTO struct {
+	Name           string `json:"name" binding:"required"`
+	DisplayName    string `json:"displayName"`
+	VersionPattern string `json:"versionPattern"`
+	Mandatory      bool   `json:"mandatory"`
+}
+
+type ProductModulePublic struct {
+	ID             uint   `json:"id"`
+	Name           string `json:"name"`
+	DisplayName    string `json:"displayName,omitempty"`
+	VersionPattern string `json:"versionPattern,omitempty"`
+	Mandatory      bool   `json:"mandatory"`
+}
+
+func toPublicProductModule(m *models.ProductModule) ProductModulePublic {
+	return ProductModulePublic{
+		ID: m.ID, Name: m.Name, DisplayName: m.DisplayName,
+		VersionPattern: m.VersionPattern, Mandatory: m.Mandatory,
+	}
+}
+
+func (in ProductModuleDTO) toInput() service.ProductModuleInput {
+	return service.ProductModuleInput{
+		Name: in.Name, DisplayName: in.DisplayName,
+		VersionPattern: in.VersionPattern, Mandatory: in.Mandatory,
+	}
+}
+
+// GET /api/products/:id/modules
+func (h ProductHandler) ListModules(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	list, err := h.Svc.ListModules(id)
+	if err != nil {
+		respondCatalogError(c, err, "produto")
+		return
+	}
+	resp := make([]ProductModulePublic, 0, len(list))
+	for i := range list {
+		resp = append(resp, toPublicProductModule(&list[i]))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+func (h ProductHandler) CreateModule(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	var in ProductModuleDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	out, err := h.Svc.CreateModule(id, in.toInput())
+	if err != nil {
+		respondCatalogError(c, err, "módulo")
+		return
+	}
+	c.JSON(http.StatusCreated, toPublicProductModule(out))
+}
+
+func (h ProductHandler) UpdateModule(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	modID, ok := paramID(c, "moduleId")
+	if !ok {
+		return
+	}
+	var in ProductModuleDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	out, err := h.Svc.UpdateModule(id, modID, in.toInput())
+	if err != nil {
+		respondCatalogError(c, err, "módulo")
+		return
+	}
+	c.JSON(http.StatusOK, toPublicProductModule(out))
+}
+
+func (h ProductHandler) DeleteModule(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	modID, ok := paramID(c, "moduleId")
+	if !ok {
+		return
+	}
+	if err := h.Svc.DeleteModule(id, modID); err != nil {
+		respondCatalogError(c, err, "módulo")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
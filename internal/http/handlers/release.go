@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime"
@@ -18,7 +19,9 @@ import (
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
 
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/markdown"
 	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
 	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/service"
 )
@@ -33,6 +36,21 @@ import (
 type ReleaseHandler struct {
 	Svc *service.ReleaseService
 
+	// traduções (nil = responde sempre no idioma original)
+	I18n *service.TranslationService
+
+	// problemas conhecidos (nil = não inclui knownIssues nas respostas)
+	Issues *service.KnownIssueService
+
+	// verificação periódica dos arquivos no file-server (nil = desligada)
+	Integrity *service.IntegrityService
+
+	// chaves dos fabricantes: todo arquivo enviado precisa vir assinado
+	Signing *service.SigningService
+
+	// tempo na lixeira antes da purga definitiva
+	TrashRetention time.Duration
+
 	// URLs públicas (para devolver ao cliente)
 	FilePublicBase string // ex: "https://files.seudominio.com/firmware"
 
@@ -49,20 +67,26 @@ type ReleaseHandler struct {
 
 
 type FirmwareLinkDTO struct {
-	Module      string `json:"module" binding:"required"`
-	Description string `json:"description" binding:"required"`
-	URL         string `json:"url" binding:"required"`
+	ID               uint   `json:"id,omitempty"` // opcional: mantém o ID da linha existente
+	Module           string `json:"module" binding:"required"`
+	Description      string `json:"description" binding:"required"`
+	URL              string `json:"url" binding:"required"`
+	Kind             string `json:"kind"`             // firmware|bootloader|checksum|release-notes-pdf|sbom|other; vazio = deduzido da URL
+	HardwareRevision string `json:"hardwareRevision"` // vazio = qualquer revisão
 }
 
 type ModuleDTO struct {
+	ID      uint   `json:"id,omitempty"`
 	Module  string `json:"module"`
 	Version string `json:"version"`
 	Updated bool   `json:"updated"`
 }
 type EntryDTO struct {
+	ID             uint   `json:"id,omitempty"`
 	ItemOrder      int    `json:"itemOrder"`
 	Classification string `json:"classification"`
 	Observation    string `json:"observation"`
+	AdvisoryID     *uint  `json:"advisoryId,omitempty"` // só em entradas de Segurança
 }
 type CreateReleaseDTO struct {
 	Version         string      `json:"version"`
@@ -75,8 +99,10 @@ type CreateReleaseDTO struct {
 	Modules         []ModuleDTO `json:"modules"`
 	Entries         []EntryDTO  `json:"entries"`
 	Links           []FirmwareLinkDTO `json:"links"` // <- NOVO
-	ProductCategory string      `json:"productCategory"`
-	ProductName     string      `json:"productName"`
+	ProductID       *uint       `json:"productId"`
+	ProductCategory string      `json:"productCategory"` // legado: ignorado, vem do catálogo
+	ProductName     string      `json:"productName"`     // legado: usado só se productId faltar
+	Tags            []string    `json:"tags"`            // na edição: ausente mantém, [] limpa
 }
 
 type deleteFileDTO struct{ URL string `json:"url"`; Path string `json:"path"` }
@@ -89,10 +115,18 @@ type deleteFileDTO struct{ URL string `json:"url"`; Path string `json:"path"` }
 */
 
 type ReleaseLinkPublic struct {
-	ID          uint   `json:"id"`
-	Module      string `json:"module"`
-	Description string `json:"description"`
-	URL         string `json:"url"`
+	ID               uint   `json:"id"`
+	Module           string `json:"module"`
+	Description      string `json:"description"`
+	URL              string `json:"url"`
+	Kind             string `json:"kind"`
+	HardwareRevision string `json:"hardwareRevision,omitempty"`
+	// preenchidos só para arquivos enviados pelo upload
+	SHA256         string `json:"sha256,omitempty"`
+	Size           int64  `json:"size,omitempty"`
+	MimeType       string `json:"mimeType,omitempty"`
+	Filename       string `json:"filename,omitempty"`
+	SignatureKeyID string `json:"signatureKeyId,omitempty"` // ver /api/products/:id/signing-keys
 }
 
 type UserPublic struct {
@@ -109,10 +143,13 @@ type ReleaseModulePublic struct {
 }
 
 type ChangelogEntryPublic struct {
-	ID             uint   `json:"id"`
-	ItemOrder      int    `json:"itemOrder"`
-	Classification string `json:"classification"`
-	Observation    string `json:"observation"`
+	ID              uint             `json:"id"`
+	ItemOrder       int              `json:"itemOrder"`
+	Classification  string           `json:"classification"`
+	Observation     string           `json:"observation"`               // Markdown
+	ObservationHTML string           `json:"observationHtml,omitempty"` // HTML sanitizado
+	AdvisoryID      *uint            `json:"advisoryId,omitempty"`
+	Advisory        *AdvisorySummary `json:"advisory,omitempty"`
 }
 
 type ReleaseResponse struct {
@@ -122,14 +159,21 @@ type ReleaseResponse struct {
 	OTA             bool                   `json:"ota"`
 	OTAObs          string                 `json:"otaObs,omitempty"`
 	ReleaseDate     time.Time              `json:"releaseDate"`
-	ImportantNote   string                 `json:"importantNote,omitempty"`
+	ImportantNote   string                 `json:"importantNote,omitempty"`     // Markdown
+	ImportantNoteHTML string               `json:"importantNoteHtml,omitempty"` // HTML sanitizado
+	ProductID       *uint                  `json:"productId,omitempty"`
 	ProductCategory string                 `json:"productCategory"`
 	ProductName     string                 `json:"productName"`
 	Status          string                 `json:"status"`
+	LockVersion     int                    `json:"lockVersion"` // mesmo valor do ETag
+	Lang               string              `json:"lang,omitempty"`               // idioma dos textos
+	TranslationMissing bool                `json:"translationMissing,omitempty"` // algum texto ficou no original
 	CreatedBy       *UserPublic            `json:"createdBy,omitempty"`
 	Modules         []ReleaseModulePublic  `json:"modules,omitempty"`
 	Entries         []ChangelogEntryPublic `json:"entries,omitempty"`
 	Links          []ReleaseLinkPublic     `json:"links,omitempty"` // <- NOVO
+	KnownIssues     []KnownIssuePublic     `json:"knownIssues,omitempty"` // em aberto nesta versão
+	Tags            []string               `json:"tags,omitempty"`
 	CreatedAt       time.Time              `json:"createdAt"`
 	UpdatedAt       time.Time              `json:"updatedAt"`
 }
@@ -145,9 +189,12 @@ func toModelLinks(ls []FirmwareLinkDTO) []models.FirmwareLink {
 	out := make([]models.FirmwareLink, 0, len(ls))
 	for _, l := range ls {
 		out = append(out, models.FirmwareLink{
-			Module:      l.Module,
-			Description: l.Description,
-			URL:         l.URL,
+			ID:               l.ID,
+			Module:           l.Module,
+			Description:      l.Description,
+			URL:              l.URL,
+			Kind:             models.LinkKind(l.Kind),
+			HardwareRevision: l.HardwareRevision,
 		})
 	}
 	return out
@@ -158,6 +205,9 @@ func toPublicLinks(ls []models.FirmwareLink) []ReleaseLinkPublic {
 	for _, l := range ls {
 		out = append(out, ReleaseLinkPublic{
 			ID: l.ID, Module: l.Module, Description: l.Description, URL: l.URL,
+			Kind: string(l.Kind), HardwareRevision: l.HardwareRevision,
+			SHA256: l.SHA256, Size: l.SizeBytes, MimeType: l.MIMEType, Filename: l.OriginalFilename,
+			SignatureKeyID: l.SignatureKeyID,
 		})
 	}
 	return out
@@ -167,6 +217,7 @@ func toModelModules(ms []ModuleDTO) []models.ReleaseModule {
 	out := make([]models.ReleaseModule, 0, len(ms))
 	for _, m := range ms {
 		out = append(out, models.ReleaseModule{
+			ID:      m.ID,
 			Module:  m.Module,
 			Version: m.Version,
 			Updated: m.Updated,
@@ -178,14 +229,36 @@ func toModelEntries(es []EntryDTO) []models.ChangelogEntry {
 	out := make([]models.ChangelogEntry, 0, len(es))
 	for _, e := range es {
 		out = append(out, models.ChangelogEntry{
+			ID:             e.ID,
 			ItemOrder:      e.ItemOrder,
 			Classification: models.EntryClassification(e.Classification),
 			Observation:    e.Observation,
+			AdvisoryID:     e.AdvisoryID,
 		})
 	}
 	return out
 }
 
+// toModelTags preserva nil (tags ausentes) e [] (limpar tags).
+func toModelTags(names []string) []models.Tag {
+	if names == nil {
+		return nil
+	}
+	out := make([]models.Tag, 0, len(names))
+	for _, n := range names {
+		out = append(out, models.Tag{Name: n})
+	}
+	return out
+}
+
+func toPublicTags(tags []models.Tag) []string {
+	out := make([]string, 0, len(tags))
+	for _, t := range tags {
+		out = append(out, t.Name)
+	}
+	return out
+}
+
 func toPublicUser(u *models.User) *UserPublic {
 	if u == nil || u.ID == 0 {
 		return nil
@@ -208,8 +281,11 @@ func toPublicEntries(es []models.ChangelogEntry) []ChangelogEntryPublic {
 	for _, e := range es {
 		out = append(out, ChangelogEntryPublic{
 			ID: e.ID, ItemOrder: e.ItemOrder,
-			Classification: string(e.Classification),
-			Observation:    e.Observation,
+			Classification:  string(e.Classification),
+			Observation:     e.Observation,
+			ObservationHTML: markdown.ToHTML(e.Observation),
+			AdvisoryID:      e.AdvisoryID,
+			Advisory:        toAdvisorySummary(e.Advisory),
 		})
 	}
 	return out
@@ -220,17 +296,68 @@ func toReleaseResponse(m *models.Release) ReleaseResponse {
 		ID: m.ID, Version: m.Version, PreviousVersion: m.PreviousVersion,
 		OTA: m.OTA, OTAObs: m.OTAObs, ReleaseDate: m.ReleaseDate,
 		ImportantNote:   m.ImportantNote,
+		ImportantNoteHTML: markdown.ToHTML(m.ImportantNote),
+		ProductID:       m.ProductID,
 		ProductCategory: m.ProductCategory,
 		ProductName:     m.ProductName,
 		Status:          string(m.Status), // <- NOVO
+		LockVersion:     m.LockVersion,
 		CreatedBy:       toPublicUser(m.CreatedBy),
 		Modules:         toPublicModules(m.Modules),
 		Entries:         toPublicEntries(m.Entries),
 		Links:           toPublicLinks(m.Links), // <- NOVO
+		Tags:            toPublicTags(m.Tags),
 		CreatedAt:       m.CreatedAt, UpdatedAt: m.UpdatedAt,
 	}
 }
 
+// traduz erros do serviço para status HTTP
+func respondSvcError(c *gin.Context, err error) {
+	switch {
+	case service.IsValidationError(err):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrDuplicateVersion),
+		errors.Is(err, service.ErrInvalidTransition),
+		errors.Is(err, service.ErrApprovalRequired),
+		errors.Is(err, service.ErrApprovalPending),
+		errors.Is(err, service.ErrNoPendingRequest),
+		errors.Is(err, service.ErrAlreadyDecided),
+		errors.Is(err, service.ErrReleasePublished):
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrStaleRelease):
+		c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error()})
+	case errors.Is(err, service.ErrTransitionForbidden),
+		errors.Is(err, service.ErrSelfApproval):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": "release não encontrado"})
+	case errors.Is(err, service.ErrNoApproval),
+		errors.Is(err, service.ErrEntryNotFound),
+		errors.Is(err, service.ErrModuleNotFound),
+		errors.Is(err, service.ErrLinkNotFound),
+		errors.Is(err, service.ErrTranslationNotFound):
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// papel do usuário autenticado (definido pelo middleware JWT)
+func ctxRole(c *gin.Context) models.Role {
+	v, _ := c.Get("role")
+	r, _ := v.(string)
+	return models.Role(r)
+}
+
+// ID do usuário autenticado; responde o erro e devolve false se ausente
+func ctxUserID(c *gin.Context) (uint, bool) {
+	uidVal, ok := c.Get("userID")
+	if !ok { c.JSON(http.StatusUnauthorized, gin.H{"error": "missing user"}); return 0, false }
+	userID, ok := uidVal.(uint)
+	if !ok || userID == 0 { c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid user id"}); return 0, false }
+	return userID, true
+}
+
 /* ===== Helpers de upload ===== */
 
 // monta destino DAV: <FileServerBase>/<dir>/<filename>
@@ -246,9 +373,9 @@ func (h ReleaseHandler) davDest(dir, filename string) (string, error) {
 }
 
 // PUT para o servidor de arquivos via WebDAV
-func (h ReleaseHandler) davPut(ctx context.Context, filename, dir string, r io.Reader) (publicURL string, size int64, err error) {
+func (h ReleaseHandler) davPut(ctx context.Context, filename, dir string, r io.Reader) (uploadedFile, error) {
     dest, err := h.davDest(dir, filename)
-    if err != nil { return "", 0, err }
+    if err != nil { return uploadedFile{}, err }
 
     mt := mime.TypeByExtension(strings.ToLower(filepath.Ext(filename)))
     if mt == "" { mt = "application/octet-stream" }
@@ -261,18 +388,18 @@ func (h ReleaseHandler) davPut(ctx context.Context, filename, dir string, r io.R
     if to == 0 { to = 120 * time.Second }
 
     req, err := http.NewRequestWithContext(ctx, http.MethodPut, dest, body)
-    if err != nil { return "", 0, err }
+    if err != nil { return uploadedFile{}, err }
     req.Header.Set("Content-Type", mt)
     if h.FileServerUser != "" {
         req.SetBasicAuth(h.FileServerUser, h.FileServerPass)
     }
 
     resp, err := (&http.Client{Timeout: to}).Do(req)
-    if err != nil { return "", 0, err }
+    if err != nil { return uploadedFile{}, err }
     defer resp.Body.Close()
     if resp.StatusCode >= 300 {
         b, _ := io.ReadAll(io.LimitReader(resp.Body, 2048))
-        return "", 0, fmt.Errorf("file-server %d: %s", resp.StatusCode, string(b))
+        return uploadedFile{}, fmt.Errorf("file-server %d: %s", resp.StatusCode, string(b))
     }
 
     // URL pública final
@@ -282,8 +409,13 @@ func (h ReleaseHandler) davPut(ctx context.Context, filename, dir string, r io.R
         if s != "" { pub += url.PathEscape(s) + "/" }
     }
     pub += url.PathEscape(filepath.Base(filename))
-    _ = hex.EncodeToString(hh.Sum(nil)) // disponível p/ log
-    return pub, cr.N, nil
+    return uploadedFile{
+        URL:      pub,
+        SHA256:   hex.EncodeToString(hh.Sum(nil)),
+        Size:     cr.N,
+        MIMEType: mt,
+        Filename: filepath.Base(filename),
+    }, nil
 }
 
 // DELETE no servidor de arquivos. Aceita URL pública completa OU caminho "AC/arquivo.bin".
@@ -329,6 +461,15 @@ func sanitizeRel(p string) (string, error) {
 	return strings.Trim(p, "/"), nil
 }
 
+// uploadedFile descreve um arquivo gravado no file-server.
+type uploadedFile struct {
+	URL      string
+	SHA256   string // hex, minúsculo
+	Size     int64
+	MIMEType string
+	Filename string // nome original (sem diretório)
+}
+
 type countReader struct{ R io.Reader; N int64 }
 func (c *countReader) Read(p []byte) (int, error) {
 	n, err := c.R.Read(p); c.N += int64(n); return n, err
@@ -344,13 +485,14 @@ func (h ReleaseHandler) makePublicURL(dir, filename string) string {
 }
 
 // salva no FS local: <FileLocalRoot>/firmware[/dir]/filename
-func (h ReleaseHandler) saveToLocal(filename, dir string, r io.Reader) (publicURL string, size int64, err error) {
-	if h.FileLocalRoot == "" { return "", 0, fmt.Errorf("FileLocalRoot não configurado") }
+func (h ReleaseHandler) saveToLocal(filename, dir string, r io.Reader) (uploadedFile, error) {
+	if h.FileLocalRoot == "" { return uploadedFile{}, fmt.Errorf("FileLocalRoot não configurado") }
 
 	root := strings.TrimRight(h.FileLocalRoot, "/")
 
+	var err error
 	if dir != "" {
-		if dir, err = sanitizeRel(dir); err != nil { return "", 0, err }
+		if dir, err = sanitizeRel(dir); err != nil { return uploadedFile{}, err }
 	}
 
 	destDir := root
@@ -358,19 +500,28 @@ func (h ReleaseHandler) saveToLocal(filename, dir string, r io.Reader) (publicUR
 		destDir = filepath.Join(destDir, filepath.FromSlash(dir))
 	}
 	if err := os.MkdirAll(destDir, 0o775); err != nil {
-		return "", 0, fmt.Errorf("falha ao criar diretório: %w", err)
+		return uploadedFile{}, fmt.Errorf("falha ao criar diretório: %w", err)
 	}
 
 	destPath := filepath.Join(destDir, filepath.Base(filename))
 	f, err := os.Create(destPath)
-	if err != nil { return "", 0, fmt.Errorf("falha ao criar arquivo: %w", err) }
+	if err != nil { return uploadedFile{}, fmt.Errorf("falha ao criar arquivo: %w", err) }
 	defer func() { _ = f.Sync(); _ = f.Close() }()
 
-	n, err := io.Copy(f, r)
-	if err != nil { return "", 0, fmt.Errorf("falha ao gravar arquivo: %w", err) }
-	if n == 0 { return "", 0, fmt.Errorf("arquivo vazio: %s", destPath) }
-
-	return h.makePublicURL(dir, filepath.Base(filename)), n, nil
+	hh := sha256.New()
+	n, err := io.Copy(f, io.TeeReader(r, hh))
+	if err != nil { return uploadedFile{}, fmt.Errorf("falha ao gravar arquivo: %w", err) }
+	if n == 0 { return uploadedFile{}, fmt.Errorf("arquivo vazio: %s", destPath) }
+
+	mt := mime.TypeByExtension(strings.ToLower(filepath.Ext(filename)))
+	if mt == "" { mt = "application/octet-stream" }
+	return uploadedFile{
+		URL:      h.makePublicURL(dir, filepath.Base(filename)),
+		SHA256:   hex.EncodeToString(hh.Sum(nil)),
+		Size:     n,
+		MIMEType: mt,
+		Filename: filepath.Base(filename),
+	}, nil
 }
 
 
@@ -402,9 +553,9 @@ func (h ReleaseHandler) Create(c *gin.Context) {
 		userID, ok := uidVal.(uint)
 		if !ok || userID == 0 { c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid user id"}); return }
 
+		// vazio: o serviço escolhe (revisao se houver fluxo de aprovação)
 		st := models.FirmwareStatus(in.Status)
-		if st == "" { st = models.FirmwareStatusProducao }
-		if !st.Valid() {
+		if st != "" && !st.Valid() {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "status inválido: use revisao|producao|descontinuado"})
 			return
 		}
@@ -416,16 +567,19 @@ func (h ReleaseHandler) Create(c *gin.Context) {
 			OTAObs:          in.OTAObs,
 			ReleaseDate:     in.ReleaseDate,
 			ImportantNote:   in.ImportantNote,
+			ProductID:       in.ProductID,
 			ProductCategory: in.ProductCategory,
 			ProductName:     in.ProductName,
 			Status:          st,
 			Modules:         toModelModules(in.Modules),
 			Entries:         toModelEntries(in.Entries),
 			Links:           toModelLinks(in.Links),
+			Tags:            toModelTags(in.Tags),
 			CreatedByUserID: userID,
 		}
-		out, err := h.Svc.Create(rel)
-		if err != nil { c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()}); return }
+		out, err := h.Svc.Create(rel, ctxRole(c))
+		if err != nil { respondSvcError(c, err); return }
+		setReleaseETag(c, out)
 		c.JSON(http.StatusCreated, toReleaseResponse(out))
 		return
 	}
@@ -448,9 +602,9 @@ func (h ReleaseHandler) Create(c *gin.Context) {
 		userID, ok := uidVal.(uint)
 		if !ok || userID == 0 { c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid user id"}); return }
 
+		// vazio: o serviço escolhe (revisao se houver fluxo de aprovação)
 		st := models.FirmwareStatus(in.Status)
-		if st == "" { st = models.FirmwareStatusProducao }
-		if !st.Valid() {
+		if st != "" && !st.Valid() {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "status inválido: use revisao|producao|descontinuado"})
 			return
 		}
@@ -470,6 +624,8 @@ func (h ReleaseHandler) Create(c *gin.Context) {
 			dir := c.PostForm("dir") // ex: "AC" ou "DC/MODELOX"
 			linkModule := strings.TrimSpace(c.PostForm("linkModule"))
 			linkDesc := strings.TrimSpace(c.PostForm("linkDescription"))
+			linkKind := strings.TrimSpace(c.PostForm("linkKind"))             // vazio = deduzido do arquivo
+			linkHWRev := strings.TrimSpace(c.PostForm("linkHardwareRevision")) // vazio = qualquer revisão
 			if linkModule == "" { linkModule = "default" }
 			if linkDesc == "" { linkDesc = "Firmware" }
 
@@ -480,7 +636,11 @@ func (h ReleaseHandler) Create(c *gin.Context) {
 			}
 			defer f.Close()
 
-			publicURL, _, err := h.davPut(c.Request.Context(), filename, dir, f)
+			// assinatura conferida antes de o arquivo chegar ao file-server
+			keyID, ok := h.verifyUpload(c, in.ProductID, in.ProductName, filename, f)
+			if !ok { return }
+
+			up, err := h.davPut(c.Request.Context(), filename, dir, f)
 			if err != nil {
     		c.JSON(http.StatusBadGateway, gin.H{"error": "upload falhou: " + err.Error()})
     		return
@@ -488,9 +648,16 @@ func (h ReleaseHandler) Create(c *gin.Context) {
 
 
 			links = append(links, models.FirmwareLink{
-				Module:      linkModule,
-				Description: linkDesc,
-				URL:         publicURL,
+				Module:           linkModule,
+				Description:      linkDesc,
+				URL:              up.URL,
+				Kind:             models.LinkKind(linkKind),
+				HardwareRevision: linkHWRev,
+				SHA256:           up.SHA256,
+				SizeBytes:        up.Size,
+				MIMEType:         up.MIMEType,
+				OriginalFilename: up.Filename,
+				SignatureKeyID:   keyID,
 			})
 		}
 
@@ -501,16 +668,19 @@ func (h ReleaseHandler) Create(c *gin.Context) {
 			OTAObs:          in.OTAObs,
 			ReleaseDate:     in.ReleaseDate,
 			ImportantNote:   in.ImportantNote,
+			ProductID:       in.ProductID,
 			ProductCategory: in.ProductCategory,
 			ProductName:     in.ProductName,
 			Status:          st,
 			Modules:         toModelModules(in.Modules),
 			Entries:         toModelEntries(in.Entries),
 			Links:           links,
+			Tags:            toModelTags(in.Tags),
 			CreatedByUserID: userID,
 		}
-		out, err := h.Svc.Create(rel)
-		if err != nil { c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()}); return }
+		out, err := h.Svc.Create(rel, ctxRole(c))
+		if err != nil { respondSvcError(c, err); return }
+		setReleaseETag(c, out)
 		c.JSON(http.StatusCreated, toReleaseResponse(out))
 		return
 	}
@@ -521,18 +691,69 @@ func (h ReleaseHandler) Create(c *gin.Context) {
 
 func (h ReleaseHandler) Get(c *gin.Context) {
 	id, _ := strconv.Atoi(c.Param("id"))
+	format, ok := textFormat(c)
+	if !ok {
+		return
+	}
 	out, err := h.Svc.Get(uint(id))
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "release não encontrado"})
 		return
 	}
-	c.JSON(http.StatusOK, toReleaseResponse(out))
+	lang, missing, err := h.localize(c, out)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	issues, err := h.openIssues(out)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	resp := toReleaseResponse(out)
+	resp.Lang, resp.TranslationMissing = lang, missing[out.ID]
+	resp.KnownIssues = toPublicKnownIssues(issues[out.ID])
+	if format == formatText {
+		resp.asPlainText()
+	}
+	h.setLocalizedETag(c, out, lang)
+	c.JSON(http.StatusOK, resp)
+}
+
+// GET /api/products/:id/releases/:version  (":id" aceita ID ou nome do produto)
+func (h ReleaseHandler) GetByProductVersion(c *gin.Context) {
+	format, ok := textFormat(c)
+	if !ok {
+		return
+	}
+	out, err := h.Svc.GetByProductVersion(c.Param("id"), c.Param("version"))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "release não encontrado"})
+			return
+		}
+		respondSvcError(c, err)
+		return
+	}
+	issues, err := h.openIssues(out)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	resp := toReleaseResponse(out)
+	resp.KnownIssues = toPublicKnownIssues(issues[out.ID])
+	if format == formatText {
+		resp.asPlainText()
+	}
+	setReleaseETag(c, out)
+	c.JSON(http.StatusOK, resp)
 }
 
 func (h ReleaseHandler) List(c *gin.Context) {
 	var (
 		q       = c.Query("q")
 		version = c.Query("version")
+		product = c.Query("product") // ID ou nome
 		df, dt  *time.Time
 	)
 	if v := c.Query("date_from"); v != "" {
@@ -545,7 +766,32 @@ func (h ReleaseHandler) List(c *gin.Context) {
 			dt = &t
 		}
 	}
-	list, err := h.Svc.List(service.ReleaseQuery{Q: q, Version: version, DateFrom: df, DateTo: dt})
+	format, ok := textFormat(c)
+	if !ok {
+		return
+	}
+	sortBy := c.DefaultQuery("sort", service.SortReleaseDate)
+	if sortBy != service.SortReleaseDate && sortBy != service.SortVersion {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "sort inválido: use release_date|version"})
+		return
+	}
+	// ?tag=LTS&tag=hotfix: releases com todas as tags
+	list, err := h.Svc.List(service.ReleaseQuery{Q: q, Version: version, Product: product, DateFrom: df, DateTo: dt, Sort: sortBy, Tags: c.QueryArray("tag")})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ptrs := make([]*models.Release, 0, len(list))
+	for i := range list {
+		ptrs = append(ptrs, &list[i])
+	}
+	lang, missing, err := h.localize(c, ptrs...)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	issues, err := h.openIssues(ptrs...)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -553,7 +799,13 @@ func (h ReleaseHandler) List(c *gin.Context) {
 
 	resp := make([]ReleaseResponse, 0, len(list))
 	for _, it := range list {
-		resp = append(resp, toReleaseResponse(&it))
+		r := toReleaseResponse(&it)
+		r.Lang, r.TranslationMissing = lang, missing[it.ID]
+		r.KnownIssues = toPublicKnownIssues(issues[it.ID])
+		if format == formatText {
+			r.asPlainText()
+		}
+		resp = append(resp, r)
 	}
 	c.JSON(http.StatusOK, resp)
 }
@@ -563,11 +815,20 @@ func (h ReleaseHandler) Update(c *gin.Context) {
 	cur, err := h.Svc.Get(uint(id))
 	if err != nil { c.JSON(http.StatusNotFound, gin.H{"error": "release não encontrado"}); return }
 
+	// concorrência otimista: o cliente precisa dizer qual versão editou
+	lockVersion, ok := ifMatchVersion(c, cur.LockVersion)
+	if !ok { return }
+
 	var in CreateReleaseDTO
 	if err := c.ShouldBindJSON(&in); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	h.saveUpdate(c, cur, in, lockVersion)
+}
+
+// saveUpdate grava o release inteiro a partir do DTO (PUT e PATCH).
+func (h ReleaseHandler) saveUpdate(c *gin.Context, cur *models.Release, in CreateReleaseDTO, lockVersion int) {
 	for i, l := range in.Links {
 		if _, err := url.ParseRequestURI(l.URL); err != nil {
 			c.JSON(http.StatusBadRequest, gin.H{"error": "link inválido na posição " + strconv.Itoa(i)})
@@ -575,12 +836,8 @@ func (h ReleaseHandler) Update(c *gin.Context) {
 		}
 	}
 
+	// status vazio mantém o atual; mudanças só via POST /:id/status
 	st := models.FirmwareStatus(in.Status)
-	if st == "" { st = models.FirmwareStatusProducao }
-	if !st.Valid() {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "status inválido: use revisao|producao|descontinuado"})
-		return
-	}
 
 	base := models.Release{
 		ID:              cur.ID,
@@ -591,53 +848,56 @@ func (h ReleaseHandler) Update(c *gin.Context) {
 		ReleaseDate:     in.ReleaseDate,
 		ImportantNote:   in.ImportantNote,
 		Status:          st,
+		ProductID:       in.ProductID,
 		ProductCategory: in.ProductCategory,
 		ProductName:     in.ProductName,
 		CreatedByUserID: cur.CreatedByUserID,
 		CreatedAt:       cur.CreatedAt,
+		LockVersion:     lockVersion,
+		Tags:            toModelTags(in.Tags),
 	}
 
+	userID, ok := ctxUserID(c)
+	if !ok { return }
+
 	out, err := h.Svc.UpdateFull(
-		uint(id),
+		cur.ID,
+		userID,
 		base,
 		toModelModules(in.Modules),
 		toModelEntries(in.Entries),
 		toModelLinks(in.Links), // <- NOVO
 	)
-	if err != nil { c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()}); return }
+	if errors.Is(err, service.ErrStaleRelease) { h.respondStale(c, cur.ID, err); return }
+	if err != nil { respondSvcError(c, err); return }
+	setReleaseETag(c, out)
 	c.JSON(http.StatusOK, toReleaseResponse(out))
 }
 
 
+// Delete move o release para a lixeira. Os arquivos remotos só são
+// apagados na purga, quando a retenção expira (ver RunTrashPurge).
 func (h ReleaseHandler) Delete(c *gin.Context) {
-    id, _ := strconv.Atoi(c.Param("id"))
-
-    // 4.1 buscar release para obter os links (se existir)
-    rel, _ := h.Svc.Get(uint(id)) // se errar aqui, seguimos com a deleção do banco
-
-    // 4.2 tentar apagar os arquivos remotos ligados a este release
-    if rel != nil && len(rel.Links) > 0 {
-        for _, lk := range rel.Links {
-            u := strings.TrimSpace(lk.URL)
-            if u == "" { continue }
-
-            // regra: só apagar o que estiver sob a sua base pública (evita deletar URLs de terceiros)
-            basePub := strings.TrimRight(h.FilePublicBase, "/") + "/"
-            if strings.HasPrefix(u, basePub) {
-                // Delete pela URL pública direta
-                _ = h.davDelete(c.Request.Context(), u)
-            }
-        }
-    }
+    id, ok := paramID(c, "id")
+    if !ok { return }
+    userID, ok := ctxUserID(c)
+    if !ok { return }
 
-    // 4.3 deletar o release no serviço
-    if err := h.Svc.Delete(uint(id)); err != nil {
-        c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+    if err := h.Svc.Delete(id, userID); err != nil {
+        respondSvcError(c, err)
         return
     }
     c.Status(http.StatusNoContent)
 }
 
+// apaga do file-server apenas o que estiver sob a base pública (evita deletar URLs de terceiros)
+func (h ReleaseHandler) deleteOwnedFile(ctx context.Context, u string) error {
+    u = strings.TrimSpace(u)
+    basePub := strings.TrimRight(h.FilePublicBase, "/") + "/"
+    if u == "" || !strings.HasPrefix(u, basePub) { return nil }
+    return h.davDelete(ctx, u)
+}
+
 
 
 func (h ReleaseHandler) DeleteFile(c *gin.Context) {
@@ -0,0 +1,107 @@
+// internal/http/handlers/release_approval.go
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+)
+
+type ApprovalCommentDTO struct {
+	Comment string `json:"comment"`
+}
+
+type ApprovalDecisionPublic struct {
+	User      *UserPublic `json:"user,omitempty"`
+	Decision  string      `json:"decision"`
+	Comment   string      `json:"comment,omitempty"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+type ApprovalRequestPublic struct {
+	ID                uint                     `json:"id"`
+	ReleaseID         uint                     `json:"releaseId"`
+	Status            string                   `json:"status"`
+	RequiredApprovals int                      `json:"requiredApprovals"`
+	Approvals         int                      `json:"approvals"`
+	SubmittedBy       *UserPublic              `json:"submittedBy,omitempty"`
+	Comment           string                   `json:"comment,omitempty"`
+	Decisions         []ApprovalDecisionPublic `json:"decisions"`
+	CreatedAt         time.Time                `json:"createdAt"`
+	ClosedAt          *time.Time               `json:"closedAt,omitempty"`
+}
+
+func toPublicApproval(r *models.ReleaseApprovalRequest) ApprovalRequestPublic {
+	out := ApprovalRequestPublic{
+		ID: r.ID, ReleaseID: r.ReleaseID, Status: string(r.Status),
+		RequiredApprovals: r.RequiredApprovals,
+		SubmittedBy:       toPublicUser(r.SubmittedBy),
+		Comment:           r.Comment,
+		Decisions:         make([]ApprovalDecisionPublic, 0, len(r.Decisions)),
+		CreatedAt:         r.CreatedAt, ClosedAt: r.ClosedAt,
+	}
+	for _, d := range r.Decisions {
+		if d.Decision == models.DecisionAprovar {
+			out.Approvals++
+		}
+		out.Decisions = append(out.Decisions, ApprovalDecisionPublic{
+			User: toPublicUser(d.User), Decision: string(d.Decision),
+			Comment: d.Comment, CreatedAt: d.CreatedAt,
+		})
+	}
+	return out
+}
+
+// GET /api/releases/:id/approval
+func (h ReleaseHandler) Approval(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	out, err := h.Svc.Approval(uint(id))
+	if err != nil {
+		respondSvcError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toPublicApproval(out))
+}
+
+// POST /api/releases/:id/approval  {comment}
+func (h ReleaseHandler) SubmitForApproval(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var in ApprovalCommentDTO
+	_ = c.ShouldBindJSON(&in) // corpo opcional
+	userID, ok := ctxUserID(c)
+	if !ok {
+		return
+	}
+	out, err := h.Svc.SubmitForApproval(uint(id), userID, in.Comment)
+	if err != nil {
+		respondSvcError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, toPublicApproval(out))
+}
+
+// POST /api/releases/:id/approval/approve  {comment}
+func (h ReleaseHandler) Approve(c *gin.Context) { h.decide(c, models.DecisionAprovar) }
+
+// POST /api/releases/:id/approval/reject  {comment} (obrigatório)
+func (h ReleaseHandler) Reject(c *gin.Context) { h.decide(c, models.DecisionRejeitar) }
+
+func (h ReleaseHandler) decide(c *gin.Context, d models.ApprovalDecision) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var in ApprovalCommentDTO
+	_ = c.ShouldBindJSON(&in)
+	userID, ok := ctxUserID(c)
+	if !ok {
+		return
+	}
+	out, err := h.Svc.Decide(uint(id), userID, d, in.Comment)
+	if err != nil {
+		respondSvcError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toPublicApproval(out))
+}
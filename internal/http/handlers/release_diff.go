@@ -0,0 +1,224 @@
+// internal/http/handlers/release_diff.go
+package handlers
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+)
+
+type FieldChange struct {
+	Field string `json:"field"`
+	From  any    `json:"from"`
+	To    any    `json:"to"`
+}
+
+type ModuleChange struct {
+	Module      string `json:"module"`
+	FromVersion string `json:"fromVersion"`
+	ToVersion   string `json:"toVersion"`
+	FromUpdated bool   `json:"fromUpdated"`
+	ToUpdated   bool   `json:"toUpdated"`
+}
+
+type ModulesDiff struct {
+	Added   []ReleaseModulePublic `json:"added"`
+	Removed []ReleaseModulePublic `json:"removed"`
+	Changed []ModuleChange        `json:"changed"`
+}
+
+type EntriesDiff struct {
+	Added   []ChangelogEntryPublic `json:"added"`
+	Removed []ChangelogEntryPublic `json:"removed"`
+}
+
+type LinksDiff struct {
+	Added   []ReleaseLinkPublic `json:"added"`
+	Removed []ReleaseLinkPublic `json:"removed"`
+}
+
+type TagsDiff struct {
+	Added   []string `json:"added"`
+	Removed []string `json:"removed"`
+}
+
+type ReleaseDiff struct {
+	Identical bool          `json:"identical"`
+	Fields    []FieldChange `json:"fields"`
+	Tags      TagsDiff      `json:"tags"`
+	Modules   ModulesDiff   `json:"modules"`
+	Entries   EntriesDiff   `json:"entries"`
+	Links     LinksDiff     `json:"links"`
+}
+
+type ReleaseDiffResponse struct {
+	Base  ReleaseResponse `json:"base"`
+	Other ReleaseResponse `json:"other"`
+	Diff  ReleaseDiff     `json:"diff"`
+}
+
+// diffReleases compara a forma normalizada de dois releases. Módulos são
+// casados pelo nome (e pela ordem, quando o nome se repete); entradas e links não têm identidade entre releases,
+// então são comparados pelo conteúdo (uma mudança aparece como remoção +
+// inclusão).
+func diffReleases(a, b ReleaseResponse) ReleaseDiff {
+	d := ReleaseDiff{
+		Fields:  []FieldChange{},
+		Tags:    TagsDiff{Added: []string{}, Removed: []string{}},
+		Modules: ModulesDiff{Added: []ReleaseModulePublic{}, Removed: []ReleaseModulePublic{}, Changed: []ModuleChange{}},
+		Entries: EntriesDiff{Added: []ChangelogEntryPublic{}, Removed: []ChangelogEntryPublic{}},
+		Links:   LinksDiff{Added: []ReleaseLinkPublic{}, Removed: []ReleaseLinkPublic{}},
+	}
+
+	field := func(name string, from, to any, changed bool) {
+		if changed {
+			d.Fields = append(d.Fields, FieldChange{Field: name, From: from, To: to})
+		}
+	}
+	field("version", a.Version, b.Version, a.Version != b.Version)
+	field("previousVersion", a.PreviousVersion, b.PreviousVersion, a.PreviousVersion != b.PreviousVersion)
+	field("ota", a.OTA, b.OTA, a.OTA != b.OTA)
+	field("otaObs", a.OTAObs, b.OTAObs, a.OTAObs != b.OTAObs)
+	field("releaseDate", a.ReleaseDate, b.ReleaseDate, !a.ReleaseDate.Equal(b.ReleaseDate))
+	field("importantNote", a.ImportantNote, b.ImportantNote, a.ImportantNote != b.ImportantNote)
+	field("productCategory", a.ProductCategory, b.ProductCategory, a.ProductCategory != b.ProductCategory)
+	field("productName", a.ProductName, b.ProductName, a.ProductName != b.ProductName)
+	field("status", a.Status, b.Status, a.Status != b.Status)
+
+	// tags: pela chave normalizada, para que só mudar a grafia não conte
+	tagKey := func(t string) string { return models.NormalizeKey(t) }
+	d.Tags.Added, d.Tags.Removed = diffBag(a.Tags, b.Tags, tagKey)
+
+	// módulos: pelo nome; nomes repetidos casam pela ordem de ocorrência
+	// (o 2º "wifi" de a com o 2º "wifi" de b), sem um sobrescrever o outro
+	am := map[string]ReleaseModulePublic{}
+	for k, m := range moduleKeys(a.Modules) {
+		am[k] = m
+	}
+	bm := moduleKeys(b.Modules)
+	for _, k := range sortedKeys(bm) {
+		m := bm[k]
+		old, ok := am[k]
+		if !ok {
+			continue
+		}
+		if old.Version != m.Version || old.Updated != m.Updated {
+			d.Modules.Changed = append(d.Modules.Changed, ModuleChange{
+				Module:      m.Module,
+				FromVersion: old.Version, ToVersion: m.Version,
+				FromUpdated: old.Updated, ToUpdated: m.Updated,
+			})
+		}
+	}
+	seen := map[string]int{}
+	for _, m := range b.Modules {
+		seen[m.Module]++
+		if _, ok := am[moduleKey(m.Module, seen[m.Module])]; !ok {
+			d.Modules.Added = append(d.Modules.Added, m)
+		}
+	}
+	seen = map[string]int{}
+	for _, m := range a.Modules {
+		seen[m.Module]++
+		if _, ok := bm[moduleKey(m.Module, seen[m.Module])]; !ok {
+			d.Modules.Removed = append(d.Modules.Removed, m)
+		}
+	}
+
+	// entradas: multiconjunto de (classificação, observação)
+	entryKey := func(e ChangelogEntryPublic) string { return e.Classification + "\x00" + e.Observation }
+	d.Entries.Added, d.Entries.Removed = diffBag(a.Entries, b.Entries, entryKey)
+
+	// links: multiconjunto de (módulo, descrição, url, tipo, revisão de
+	// hardware, checksum, chave da assinatura): trocar o arquivo ou para
+	// qual hardware ele vale também é mudança
+	linkKey := func(l ReleaseLinkPublic) string {
+		return strings.Join([]string{l.Module, l.Description, l.URL, l.Kind, l.HardwareRevision, l.SHA256, l.SignatureKeyID}, "\x00")
+	}
+	d.Links.Added, d.Links.Removed = diffBag(a.Links, b.Links, linkKey)
+
+	d.Identical = len(d.Fields) == 0 &&
+		len(d.Tags.Added)+len(d.Tags.Removed) == 0 &&
+		len(d.Modules.Added)+len(d.Modules.Removed)+len(d.Modules.Changed) == 0 &&
+		len(d.Entries.Added)+len(d.Entries.Removed) == 0 &&
+		len(d.Links.Added)+len(d.Links.Removed) == 0
+	return d
+}
+
+func moduleKey(name string, n int) string {
+	return name + "\x00" + strconv.Itoa(n)
+}
+
+// moduleKeys indexa os módulos por nome + ocorrência (1, 2, ...).
+func moduleKeys(ms []ReleaseModulePublic) map[string]ReleaseModulePublic {
+	out := make(map[string]ReleaseModulePublic, len(ms))
+	seen := map[string]int{}
+	for _, m := range ms {
+		seen[m.Module]++
+		out[moduleKey(m.Module, seen[m.Module])] = m
+	}
+	return out
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// diffBag devolve os itens de b sem par em a (added) e os de a sem par em b (removed).
+func diffBag[T any](a, b []T, key func(T) string) (added, removed []T) {
+	added, removed = []T{}, []T{}
+	count := map[string]int{}
+	for _, x := range a {
+		count[key(x)]++
+	}
+	for _, x := range b {
+		k := key(x)
+		if count[k] > 0 {
+			count[k]--
+			continue
+		}
+		added = append(added, x)
+	}
+	for _, x := range a {
+		k := key(x)
+		if count[k] > 0 {
+			count[k]--
+			removed = append(removed, x)
+		}
+	}
+	return added, removed
+}
+
+// GET /api/releases/:id/diff/:otherId
+func (h ReleaseHandler) Diff(c *gin.Context) {
+	id, err1 := strconv.Atoi(c.Param("id"))
+	otherID, err2 := strconv.Atoi(c.Param("otherId"))
+	if err1 != nil || err2 != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "ids inválidos"})
+		return
+	}
+
+	base, err := h.Svc.Get(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "release não encontrado"})
+		return
+	}
+	other, err := h.Svc.Get(uint(otherID))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "release não encontrado"})
+		return
+	}
+
+	a, b := toReleaseResponse(base), toReleaseResponse(other)
+	c.JSON(http.StatusOK, ReleaseDiffResponse{Base: a, Other: b, Diff: diffReleases(a, b)})
+}
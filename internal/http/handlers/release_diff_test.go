@@ -0,0 +1,106 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/http/handlers"
+)
+
+func mod(name, version string) handlers.ReleaseModulePublic {
+	return handlers.ReleaseModulePublic{Module: name, Version: version}
+}
+
+func TestDiffReleases_Identical(t *testing.T) {
+	r := handlers.ReleaseResponse{
+		Version: "1.0.0",
+		Modules: []handlers.ReleaseModulePublic{mod("wifi", "2.0"), mod("wifi", "2.1")},
+		Entries: []handlers.ChangelogEntryPublic{{Classification: "Correção", Observation: "x"}},
+	}
+	if d := handlers.DiffReleases(r, r); !d.Identical {
+		t.Fatalf("esperava idênticos: %+v", d)
+	}
+}
+
+func TestDiffReleases_Modules(t *testing.T) {
+	cases := []struct {
+		name                    string
+		a, b                    []handlers.ReleaseModulePublic
+		added, removed, changed int
+	}{
+		{"versão muda", []handlers.ReleaseModulePublic{mod("wifi", "1")}, []handlers.ReleaseModulePublic{mod("wifi", "2")}, 0, 0, 1},
+		{"módulo novo", nil, []handlers.ReleaseModulePublic{mod("ble", "1")}, 1, 0, 0},
+		{"módulo removido", []handlers.ReleaseModulePublic{mod("ble", "1")}, nil, 0, 1, 0},
+		// nomes repetidos não se sobrescrevem: casam pela ordem
+		{"repetido igual", []handlers.ReleaseModulePublic{mod("wifi", "1"), mod("wifi", "2")},
+			[]handlers.ReleaseModulePublic{mod("wifi", "1"), mod("wifi", "2")}, 0, 0, 0},
+		{"repetido muda o 2º", []handlers.ReleaseModulePublic{mod("wifi", "1"), mod("wifi", "2")},
+			[]handlers.ReleaseModulePublic{mod("wifi", "1"), mod("wifi", "3")}, 0, 0, 1},
+		{"repetido some", []handlers.ReleaseModulePublic{mod("wifi", "1"), mod("wifi", "2")},
+			[]handlers.ReleaseModulePublic{mod("wifi", "1")}, 0, 1, 0},
+	}
+	for _, tc := range cases {
+		d := handlers.DiffReleases(handlers.ReleaseResponse{Modules: tc.a}, handlers.ReleaseResponse{Modules: tc.b})
+		if len(d.Modules.Added) != tc.added || len(d.Modules.Removed) != tc.removed || len(d.Modules.Changed) != tc.changed {
+			t.Fatalf("%s: added=%d removed=%d changed=%d", tc.name, len(d.Modules.Added), len(d.Modules.Removed), len(d.Modules.Changed))
+		}
+		if d.Identical != (tc.added+tc.removed+tc.changed == 0) {
+			t.Fatalf("%s: identical=%v", tc.name, d.Identical)
+		}
+	}
+}
+
+func TestDiffReleases_EntriesAndFields(t *testing.T) {
+	a := handlers.ReleaseResponse{
+		Version: "1.0.0",
+		Entries: []handlers.ChangelogEntryPublic{{Classification: "Correção", Observation: "x"}, {Classification: "Correção", Observation: "x"}},
+	}
+	b := handlers.ReleaseResponse{
+		Version: "1.0.1",
+		Entries: []handlers.ChangelogEntryPublic{{Classification: "Correção", Observation: "x"}, {Classification: "Melhoria", Observation: "y"}},
+	}
+	d := handlers.DiffReleases(a, b)
+	if len(d.Fields) != 1 || d.Fields[0].Field != "version" {
+		t.Fatalf("fields = %+v", d.Fields)
+	}
+	if len(d.Entries.Added) != 1 || d.Entries.Added[0].Observation != "y" || len(d.Entries.Removed) != 1 {
+		t.Fatalf("entries = %+v", d.Entries)
+	}
+}
+
+func TestDiffReleases_Links(t *testing.T) {
+	base := handlers.ReleaseLinkPublic{Module: "default", Description: "Firmware", URL: "https://f/x.bin", Kind: "firmware", SHA256: "aa"}
+	for _, tc := range []struct {
+		name string
+		edit func(l *handlers.ReleaseLinkPublic)
+		same bool
+	}{
+		{"igual", func(*handlers.ReleaseLinkPublic) {}, true},
+		{"só o ID", func(l *handlers.ReleaseLinkPublic) { l.ID = 9 }, true},
+		{"kind", func(l *handlers.ReleaseLinkPublic) { l.Kind = "bootloader" }, false},
+		{"revisão de hardware", func(l *handlers.ReleaseLinkPublic) { l.HardwareRevision = "B" }, false},
+		{"checksum", func(l *handlers.ReleaseLinkPublic) { l.SHA256 = "bb" }, false},
+		{"assinatura", func(l *handlers.ReleaseLinkPublic) { l.SignatureKeyID = "k1" }, false},
+	} {
+		other := base
+		tc.edit(&other)
+		d := handlers.DiffReleases(
+			handlers.ReleaseResponse{Links: []handlers.ReleaseLinkPublic{base}},
+			handlers.ReleaseResponse{Links: []handlers.ReleaseLinkPublic{other}},
+		)
+		if d.Identical != tc.same || !tc.same && (len(d.Links.Added) != 1 || len(d.Links.Removed) != 1) {
+			t.Errorf("%s: diff = %+v", tc.name, d.Links)
+		}
+	}
+}
+
+func TestDiffReleases_Tags(t *testing.T) {
+	a := handlers.ReleaseResponse{Tags: []string{"LTS", "beta"}}
+	b := handlers.ReleaseResponse{Tags: []string{"lts", "Cliente X"}}
+	d := handlers.DiffReleases(a, b)
+	if d.Identical {
+		t.Fatal("troca de tag deveria aparecer no diff")
+	}
+	if len(d.Tags.Added) != 1 || d.Tags.Added[0] != "Cliente X" || len(d.Tags.Removed) != 1 || d.Tags.Removed[0] != "beta" {
+		t.Fatalf("tags = %+v", d.Tags)
+	}
+}
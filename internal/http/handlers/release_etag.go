@@ -0,0 +1,109 @@
+// internal/http/handlers/release_etag.go
+package handlers
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+)
+
+// O ETag de um release é o seu lock_version entre aspas, ex.: "7".
+func releaseETag(m *models.Release) string {
+	return `"` + strconv.Itoa(m.LockVersion) + `"`
+}
+
+func setReleaseETag(c *gin.Context, m *models.Release) {
+	c.Header("ETag", releaseETag(m))
+}
+
+// setLocalizedETag marca respostas traduzidas com o idioma ("7-es"): esse
+// ETag não casa em If-Match, para que texto traduzido não seja gravado
+// por engano como original.
+func (h ReleaseHandler) setLocalizedETag(c *gin.Context, m *models.Release, lang string) {
+	if h.I18n == nil || lang == h.I18n.Languages().Source {
+		setReleaseETag(c, m)
+		return
+	}
+	c.Header("ETag", `"`+strconv.Itoa(m.LockVersion)+"-"+lang+`"`)
+}
+
+// parseIfMatch lê a lista de ETags de If-Match ("7", "8" ou *). If-Match
+// usa comparação forte: ETags fracos (W/"7") e os de respostas traduzidas
+// ("7-es") nunca casam e por isso são descartados.
+func parseIfMatch(h string) (versions []int, wildcard bool) {
+	for _, tag := range strings.Split(h, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return nil, true
+		}
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+		if n, err := strconv.Atoi(tag[1 : len(tag)-1]); err == nil {
+			versions = append(versions, n)
+		}
+	}
+	return versions, false
+}
+
+// matchIfMatch devolve current se If-Match casa com ele e -1 (nunca casa)
+// caso contrário.
+func matchIfMatch(h string, current int) int {
+	versions, wildcard := parseIfMatch(h)
+	if wildcard || slices.Contains(versions, current) {
+		return current
+	}
+	return -1
+}
+
+// ifMatchVersion confere If-Match contra o lock_version atual do release.
+// Responde 428 se o cabeçalho faltar; se não casar, devolve -1, que o
+// serviço trata como edição desatualizada (412).
+func ifMatchVersion(c *gin.Context, current int) (int, bool) {
+	h := strings.TrimSpace(c.GetHeader("If-Match"))
+	if h == "" {
+		c.JSON(http.StatusPreconditionRequired, gin.H{"error": "cabeçalho If-Match é obrigatório (use o ETag do GET)"})
+		return 0, false
+	}
+	return matchIfMatch(h, current), true
+}
+
+// optionalIfMatch devolve o lock_version de If-Match para as edições de
+// relações, ou nil se ausente ou * (qualquer versão existente serve). Só
+// consulta o release quando a lista tem mais de uma versão.
+func (h ReleaseHandler) optionalIfMatch(c *gin.Context, id uint) *int {
+	hdr := strings.TrimSpace(c.GetHeader("If-Match"))
+	if hdr == "" {
+		return nil
+	}
+	versions, wildcard := parseIfMatch(hdr)
+	n := -1
+	switch {
+	case wildcard:
+		return nil
+	case len(versions) == 1:
+		n = versions[0]
+	case len(versions) > 1:
+		if cur, err := h.Svc.Get(id); err == nil {
+			n = matchIfMatch(hdr, cur.LockVersion)
+		}
+	}
+	return &n
+}
+
+// respondStale devolve 412 com o estado atual do release para o cliente
+// comparar e reaplicar suas mudanças.
+func (h ReleaseHandler) respondStale(c *gin.Context, id uint, err error) {
+	cur, gerr := h.Svc.Get(id)
+	if gerr != nil {
+		respondSvcError(c, gerr)
+		return
+	}
+	setReleaseETag(c, cur)
+	c.JSON(http.StatusPreconditionFailed, gin.H{"error": err.Error(), "current": toReleaseResponse(cur)})
+}
@@ -0,0 +1,28 @@
+package handlers_test
+
+import (
+	"testing"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/http/handlers"
+)
+
+func TestMatchIfMatch(t *testing.T) {
+	for _, tc := range []struct {
+		header string
+		want   int
+	}{
+		{`"7"`, 7},
+		{`"6"`, -1},
+		{`*`, 7},
+		{`"5", "7"`, 7},
+		{`"5","6"`, -1},
+		{`W/"7"`, -1},
+		{`W/"7", "7"`, 7},
+		{`"7-es"`, -1},
+		{`7`, -1},
+	} {
+		if got := handlers.MatchIfMatch(tc.header, 7); got != tc.want {
+			t.Errorf("If-Match %s: got %d, want %d", tc.header, got, tc.want)
+		}
+	}
+}
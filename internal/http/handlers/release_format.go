@@ -0,0 +1,37 @@
+// internal/http/handlers/release_format.go
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/markdown"
+)
+
+// Formatos dos textos na resposta (?format=):
+//   - markdown (padrão): texto como gravado + campos ...Html renderizados
+//   - text: texto puro, sem marcação nem HTML (telas de dispositivos)
+const (
+	formatMarkdown = "markdown"
+	formatText     = "text"
+)
+
+func textFormat(c *gin.Context) (string, bool) {
+	switch f := c.DefaultQuery("format", formatMarkdown); f {
+	case formatMarkdown, formatText:
+		return f, true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format inválido: use markdown|text"})
+		return "", false
+	}
+}
+
+// asPlainText troca o Markdown por texto puro e remove o HTML.
+func (r *ReleaseResponse) asPlainText() {
+	r.ImportantNote, r.ImportantNoteHTML = markdown.ToText(r.ImportantNote), ""
+	r.OTAObs = markdown.ToText(r.OTAObs)
+	for i := range r.Entries {
+		r.Entries[i].Observation, r.Entries[i].ObservationHTML = markdown.ToText(r.Entries[i].Observation), ""
+	}
+}
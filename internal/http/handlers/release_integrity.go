@@ -0,0 +1,220 @@
+// internal/http/handlers/release_integrity.go
+package handlers
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/service"
+)
+
+// Verificação de integridade: os arquivos sob FilePublicBase são baixados
+// de novo e o SHA-256 comparado com o gravado no upload.
+
+type LinkVerificationPublic struct {
+	ID             uint      `json:"id"`
+	LinkID         uint      `json:"linkId"`
+	ReleaseID      uint      `json:"releaseId"`
+	URL            string    `json:"url"`
+	Result         string    `json:"result"` // ok|mismatch|changed|missing|error
+	ExpectedSHA256 string    `json:"expectedSha256,omitempty"`
+	ActualSHA256   string    `json:"actualSha256,omitempty"`
+	ExpectedSize   int64     `json:"expectedSize,omitempty"`
+	ActualSize     int64     `json:"actualSize,omitempty"`
+	HTTPStatus     int       `json:"httpStatus,omitempty"`
+	Error          string    `json:"error,omitempty"`
+	CheckedAt      time.Time `json:"checkedAt"`
+}
+
+type IntegrityReportPublic struct {
+	StartedAt  time.Time                `json:"startedAt"`
+	FinishedAt time.Time                `json:"finishedAt"`
+	Checked    int                      `json:"checked"`
+	Counts     map[string]int           `json:"counts"`
+	Problems   []LinkVerificationPublic `json:"problems"`
+}
+
+type IntegrityStatusPublic struct {
+	State     string                 `json:"state"` // idle|queued|running
+	QueuedAt  *time.Time             `json:"queuedAt,omitempty"`
+	StartedAt *time.Time             `json:"startedAt,omitempty"`
+	Last      *IntegrityReportPublic `json:"last,omitempty"`
+	LastError string                 `json:"lastError,omitempty"`
+}
+
+type IntegritySummaryPublic struct {
+	Links         int                      `json:"links"`
+	Unchecked     int                      `json:"unchecked"`
+	LastCheckedAt *time.Time               `json:"lastCheckedAt,omitempty"`
+	Counts        map[string]int           `json:"counts"`
+	Problems      []LinkVerificationPublic `json:"problems"`
+}
+
+func toPublicVerifications(list []models.LinkVerification) []LinkVerificationPublic {
+	out := make([]LinkVerificationPublic, 0, len(list))
+	for _, v := range list {
+		out = append(out, LinkVerificationPublic{
+			ID: v.ID, LinkID: v.LinkID, ReleaseID: v.ReleaseID, URL: v.URL, Result: string(v.Result),
+			ExpectedSHA256: v.ExpectedSHA256, ActualSHA256: v.ActualSHA256,
+			ExpectedSize: v.ExpectedSize, ActualSize: v.ActualSize,
+			HTTPStatus: v.HTTPStatus, Error: v.Error, CheckedAt: v.CheckedAt,
+		})
+	}
+	return out
+}
+
+func toPublicReport(rep *service.IntegrityReport) *IntegrityReportPublic {
+	if rep == nil {
+		return nil
+	}
+	return &IntegrityReportPublic{
+		StartedAt: rep.StartedAt, FinishedAt: rep.FinishedAt, Checked: rep.Checked,
+		Counts: toPublicCounts(rep.Counts), Problems: toPublicVerifications(rep.Problems),
+	}
+}
+
+func toPublicIntegrityStatus(st service.IntegrityStatus) IntegrityStatusPublic {
+	return IntegrityStatusPublic{
+		State: string(st.State), QueuedAt: st.QueuedAt, StartedAt: st.StartedAt,
+		Last: toPublicReport(st.Last), LastError: st.LastError,
+	}
+}
+
+func toPublicCounts(m map[models.VerificationResult]int) map[string]int {
+	out := make(map[string]int, len(m))
+	for k, n := range m {
+		out[string(k)] = n
+	}
+	return out
+}
+
+// fetchDigest baixa a URL pública e calcula o SHA-256 sem guardar o arquivo.
+func (h ReleaseHandler) fetchDigest(ctx context.Context, u string) (service.FileDigest, error) {
+	to := h.HTTPTimeout
+	if to == 0 {
+		to = 120 * time.Second
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return service.FileDigest{}, err
+	}
+	resp, err := (&http.Client{Timeout: to}).Do(req)
+	if err != nil {
+		return service.FileDigest{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return service.FileDigest{Status: resp.StatusCode}, nil
+	}
+	hh := sha256.New()
+	n, err := io.Copy(hh, resp.Body)
+	if err != nil {
+		return service.FileDigest{}, err
+	}
+	return service.FileDigest{Status: resp.StatusCode, SHA256: hex.EncodeToString(hh.Sum(nil)), Size: n}, nil
+}
+
+func (h ReleaseHandler) integrityPrefix() string {
+	return strings.TrimRight(h.FilePublicBase, "/") + "/"
+}
+
+// RunIntegrityChecks é o worker da verificação: roda a cada intervalo
+// (every <= 0 desliga o agendamento) e quando pedido via API. Bloqueia até
+// ctx terminar.
+func (h ReleaseHandler) RunIntegrityChecks(ctx context.Context, every time.Duration) {
+	var tick <-chan time.Time
+	if every > 0 {
+		t := time.NewTicker(every)
+		defer t.Stop()
+		tick = t.C
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-tick:
+		case <-h.Integrity.Requests():
+		}
+		rep, err := h.Integrity.Run(ctx, h.integrityPrefix(), h.fetchDigest)
+		if errors.Is(err, service.ErrIntegrityRunning) {
+			continue
+		}
+		if err != nil {
+			log.Printf("integridade: verificação falhou: %v", err)
+		}
+		if rep != nil && len(rep.Problems) > 0 {
+			log.Printf("integridade: %d arquivo(s) conferido(s), %d com problema", rep.Checked, len(rep.Problems))
+			for _, v := range rep.Problems {
+				log.Printf("integridade: link %d (release %d) %s: %s", v.LinkID, v.ReleaseID, v.Result, v.URL)
+			}
+		}
+	}
+}
+
+// POST /api/releases/integrity/run  (enfileira uma rodada; acompanhe em
+// GET /api/releases/integrity/run)
+func (h ReleaseHandler) RunIntegrityCheck(c *gin.Context) {
+	st, err := h.Integrity.Enqueue()
+	if errors.Is(err, service.ErrIntegrityRunning) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error(), "status": toPublicIntegrityStatus(st)})
+		return
+	}
+	c.Header("Location", "/api/releases/integrity/run")
+	c.JSON(http.StatusAccepted, toPublicIntegrityStatus(st))
+}
+
+// GET /api/releases/integrity/run  (rodada pedida/em andamento e a última)
+func (h ReleaseHandler) IntegrityRunStatus(c *gin.Context) {
+	c.JSON(http.StatusOK, toPublicIntegrityStatus(h.Integrity.Status()))
+}
+
+// GET /api/releases/integrity  (última conferência de cada link)
+func (h ReleaseHandler) IntegritySummary(c *gin.Context) {
+	sum, err := h.Integrity.Summary(h.integrityPrefix())
+	if err != nil {
+		respondSvcError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, IntegritySummaryPublic{
+		Links: sum.Links, Unchecked: sum.Unchecked, LastCheckedAt: sum.LastCheckedAt,
+		Counts: toPublicCounts(sum.Counts), Problems: toPublicVerifications(sum.Problems),
+	})
+}
+
+// GET /api/releases/:id/links/:linkId/verifications?limit=50
+func (h ReleaseHandler) LinkVerifications(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	linkID, ok := paramID(c, "linkId")
+	if !ok {
+		return
+	}
+	limit := 50
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit inválido"})
+			return
+		}
+		limit = n // 0 = todas
+	}
+	list, err := h.Integrity.History(id, linkID, limit)
+	if err != nil {
+		respondCatalogError(c, err, "link")
+		return
+	}
+	c.JSON(http.StatusOK, toPublicVerifications(list))
+}
@@ -0,0 +1,114 @@
+// internal/http/handlers/release_patch.go
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/mergepatch"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+)
+
+// releaseDocument é o release no formato do corpo do PUT, com os IDs das
+// linhas; é sobre ele que o merge patch é aplicado.
+func releaseDocument(m *models.Release) CreateReleaseDTO {
+	doc := CreateReleaseDTO{
+		Version:         m.Version,
+		PreviousVersion: m.PreviousVersion,
+		OTA:             m.OTA,
+		OTAObs:          m.OTAObs,
+		ReleaseDate:     m.ReleaseDate,
+		ImportantNote:   m.ImportantNote,
+		Status:          string(m.Status),
+		ProductID:       m.ProductID,
+		ProductCategory: m.ProductCategory,
+		ProductName:     m.ProductName,
+		Modules:         make([]ModuleDTO, 0, len(m.Modules)),
+		Entries:         make([]EntryDTO, 0, len(m.Entries)),
+		Links:           make([]FirmwareLinkDTO, 0, len(m.Links)),
+		Tags:            toPublicTags(m.Tags),
+	}
+	for _, x := range m.Modules {
+		doc.Modules = append(doc.Modules, ModuleDTO{ID: x.ID, Module: x.Module, Version: x.Version, Updated: x.Updated})
+	}
+	for _, x := range m.Entries {
+		doc.Entries = append(doc.Entries, EntryDTO{ID: x.ID, ItemOrder: x.ItemOrder, Classification: string(x.Classification), Observation: x.Observation, AdvisoryID: x.AdvisoryID})
+	}
+	for _, x := range m.Links {
+		doc.Links = append(doc.Links, FirmwareLinkDTO{ID: x.ID, Module: x.Module, Description: x.Description, URL: x.URL, Kind: string(x.Kind), HardwareRevision: x.HardwareRevision})
+	}
+	return doc
+}
+
+// PATCH /api/releases/:id  (application/merge-patch+json, RFC 7396)
+//
+// Campos ausentes ficam como estão; null limpa o campo. Arrays (modules,
+// entries, links) são trocados por inteiro, como manda a RFC: para mexer
+// em um item só, use os sub-recursos /entries, /modules e /links.
+// If-Match é opcional; sem ele, o patch vale sobre o estado atual.
+func (h ReleaseHandler) Patch(c *gin.Context) {
+	switch c.ContentType() {
+	case "application/merge-patch+json", "application/json":
+	default:
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "use application/merge-patch+json"})
+		return
+	}
+
+	id, _ := strconv.Atoi(c.Param("id"))
+	cur, err := h.Svc.Get(uint(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "release não encontrado"})
+		return
+	}
+	// sem If-Match o patch vale sobre a versão lida agora
+	lockVersion := cur.LockVersion
+	if strings.TrimSpace(c.GetHeader("If-Match")) != "" {
+		lockVersion, _ = ifMatchVersion(c, cur.LockVersion)
+	}
+
+	patch, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	doc, err := json.Marshal(releaseDocument(cur))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	merged, err := mergepatch.Apply(doc, patch)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(merged) == 0 || merged[0] != '{' {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "o patch deve ser um objeto JSON"})
+		return
+	}
+
+	var in CreateReleaseDTO
+	if err := json.Unmarshal(merged, &in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "patch gera um release inválido: " + err.Error()})
+		return
+	}
+	// o documento base sempre tem "tags"; se sumiu, o patch mandou
+	// "tags": null, que limpa (Tags nil no DTO significaria manter)
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(merged, &members); err == nil {
+		if _, ok := members["tags"]; !ok {
+			in.Tags = []string{}
+		}
+	}
+	if err := binding.Validator.ValidateStruct(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.saveUpdate(c, cur, in, lockVersion)
+}
@@ -0,0 +1,411 @@
+// internal/http/handlers/release_relations.go
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/service"
+)
+
+// Sub-recursos do release. If-Match é opcional aqui: sem ele, a alteração
+// é aplicada sobre o estado mais recente; com ele, vale a mesma regra do PUT.
+
+type EntryOrderDTO struct {
+	IDs []uint `json:"ids" binding:"required"`
+}
+
+// relationTarget lê :id, o ID do item (se itemParam != "") e o usuário.
+func relationTarget(c *gin.Context, itemParam string) (id, itemID, userID uint, ok bool) {
+	if id, ok = paramID(c, "id"); !ok {
+		return
+	}
+	if itemParam != "" {
+		if itemID, ok = paramID(c, itemParam); !ok {
+			return
+		}
+	}
+	userID, ok = ctxUserID(c)
+	return
+}
+
+func (h ReleaseHandler) respondRelationError(c *gin.Context, id uint, err error) {
+	if errors.Is(err, service.ErrStaleRelease) {
+		h.respondStale(c, id, err)
+		return
+	}
+	respondSvcError(c, err)
+}
+
+func validLinkURL(c *gin.Context, u string) bool {
+	if _, err := url.ParseRequestURI(u); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "link inválido"})
+		return false
+	}
+	return true
+}
+
+func findPublicEntry(out *models.Release, id uint) *ChangelogEntryPublic {
+	for _, e := range toPublicEntries(out.Entries) {
+		if e.ID == id {
+			return &e
+		}
+	}
+	return nil
+}
+
+func findPublicModule(out *models.Release, id uint) *ReleaseModulePublic {
+	for _, m := range toPublicModules(out.Modules) {
+		if m.ID == id {
+			return &m
+		}
+	}
+	return nil
+}
+
+func findPublicLink(out *models.Release, id uint) *ReleaseLinkPublic {
+	for _, l := range toPublicLinks(out.Links) {
+		if l.ID == id {
+			return &l
+		}
+	}
+	return nil
+}
+
+/* ===== Entradas ===== */
+
+// POST /api/releases/:id/entries
+func (h ReleaseHandler) AddEntry(c *gin.Context) {
+	id, _, userID, ok := relationTarget(c, "")
+	if !ok {
+		return
+	}
+	var in EntryDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	out, newID, err := h.Svc.AddEntry(id, userID, h.optionalIfMatch(c, id), toModelEntries([]EntryDTO{in})[0])
+	if err != nil {
+		h.respondRelationError(c, id, err)
+		return
+	}
+	setReleaseETag(c, out)
+	c.Header("Location", fmt.Sprintf("/api/releases/%d/entries/%d", id, newID))
+	c.JSON(http.StatusCreated, findPublicEntry(out, newID))
+}
+
+// PUT /api/releases/:id/entries/:entryId  (itemOrder > 0 move a entrada)
+func (h ReleaseHandler) UpdateEntry(c *gin.Context) {
+	id, entryID, userID, ok := relationTarget(c, "entryId")
+	if !ok {
+		return
+	}
+	var in EntryDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	out, err := h.Svc.UpdateEntry(id, entryID, userID, h.optionalIfMatch(c, id), toModelEntries([]EntryDTO{in})[0])
+	if err != nil {
+		h.respondRelationError(c, id, err)
+		return
+	}
+	setReleaseETag(c, out)
+	c.JSON(http.StatusOK, findPublicEntry(out, entryID))
+}
+
+// DELETE /api/releases/:id/entries/:entryId
+func (h ReleaseHandler) DeleteEntry(c *gin.Context) {
+	id, entryID, userID, ok := relationTarget(c, "entryId")
+	if !ok {
+		return
+	}
+	out, err := h.Svc.DeleteEntry(id, entryID, userID, h.optionalIfMatch(c, id))
+	if err != nil {
+		h.respondRelationError(c, id, err)
+		return
+	}
+	setReleaseETag(c, out)
+	c.Status(http.StatusNoContent)
+}
+
+// PUT /api/releases/:id/entries/order  {ids: [...]}
+func (h ReleaseHandler) ReorderEntries(c *gin.Context) {
+	id, _, userID, ok := relationTarget(c, "")
+	if !ok {
+		return
+	}
+	var in EntryOrderDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	out, err := h.Svc.ReorderEntries(id, userID, h.optionalIfMatch(c, id), in.IDs)
+	if err != nil {
+		h.respondRelationError(c, id, err)
+		return
+	}
+	setReleaseETag(c, out)
+	c.JSON(http.StatusOK, toPublicEntries(out.Entries))
+}
+
+/* ===== Módulos ===== */
+
+// POST /api/releases/:id/modules
+func (h ReleaseHandler) AddModule(c *gin.Context) {
+	id, _, userID, ok := relationTarget(c, "")
+	if !ok {
+		return
+	}
+	var in ModuleDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	out, newID, err := h.Svc.AddModule(id, userID, h.optionalIfMatch(c, id), toModelModules([]ModuleDTO{in})[0])
+	if err != nil {
+		h.respondRelationError(c, id, err)
+		return
+	}
+	setReleaseETag(c, out)
+	c.Header("Location", fmt.Sprintf("/api/releases/%d/modules/%d", id, newID))
+	c.JSON(http.StatusCreated, findPublicModule(out, newID))
+}
+
+// PUT /api/releases/:id/modules/:moduleId
+func (h ReleaseHandler) UpdateModule(c *gin.Context) {
+	id, moduleID, userID, ok := relationTarget(c, "moduleId")
+	if !ok {
+		return
+	}
+	var in ModuleDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	out, err := h.Svc.UpdateModule(id, moduleID, userID, h.optionalIfMatch(c, id), toModelModules([]ModuleDTO{in})[0])
+	if err != nil {
+		h.respondRelationError(c, id, err)
+		return
+	}
+	setReleaseETag(c, out)
+	c.JSON(http.StatusOK, findPublicModule(out, moduleID))
+}
+
+// DELETE /api/releases/:id/modules/:moduleId
+func (h ReleaseHandler) DeleteModule(c *gin.Context) {
+	id, moduleID, userID, ok := relationTarget(c, "moduleId")
+	if !ok {
+		return
+	}
+	out, err := h.Svc.DeleteModule(id, moduleID, userID, h.optionalIfMatch(c, id))
+	if err != nil {
+		h.respondRelationError(c, id, err)
+		return
+	}
+	setReleaseETag(c, out)
+	c.Status(http.StatusNoContent)
+}
+
+/* ===== Links ===== */
+
+// GET /api/releases/:id/links?kind=firmware&hardwareRevision=B
+// Links sem revisão de hardware valem para qualquer revisão.
+func (h ReleaseHandler) ListLinks(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	kind := models.ParseLinkKind(c.Query("kind"))
+	if kind != "" && !kind.Valid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "kind inválido: use firmware|bootloader|checksum|release-notes-pdf|sbom|other"})
+		return
+	}
+	out, err := h.Svc.Get(id)
+	if err != nil {
+		respondSvcError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toPublicLinks(service.MatchLinks(out.Links, kind, c.Query("hardwareRevision"))))
+}
+
+// GET /api/releases/:id/links/:linkId/sha256
+// Arquivo .sha256 no formato do sha256sum ("<hash>  <arquivo>"), para
+// conferir o download com "sha256sum -c".
+func (h ReleaseHandler) LinkChecksum(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	linkID, ok := paramID(c, "linkId")
+	if !ok {
+		return
+	}
+	out, err := h.Svc.Get(id)
+	if err != nil {
+		respondSvcError(c, err)
+		return
+	}
+	l := findPublicLink(out, linkID)
+	if l == nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "link não encontrado"})
+		return
+	}
+	if l.SHA256 == "" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "link sem checksum registrado (não foi enviado pelo upload)"})
+		return
+	}
+	name := l.Filename
+	if name == "" {
+		name = path.Base(l.URL)
+		if u, err := url.Parse(l.URL); err == nil {
+			name = path.Base(u.Path)
+		}
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name+".sha256"))
+	c.String(http.StatusOK, "%s  %s\n", l.SHA256, name)
+}
+
+// POST /api/releases/:id/links  (JSON com a URL, ou multipart com o arquivo)
+func (h ReleaseHandler) AddLink(c *gin.Context) {
+	id, _, userID, ok := relationTarget(c, "")
+	if !ok {
+		return
+	}
+	if strings.HasPrefix(c.ContentType(), "multipart/form-data") {
+		h.addUploadedLink(c, id, userID)
+		return
+	}
+	var in FirmwareLinkDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !validLinkURL(c, in.URL) {
+		return
+	}
+	out, newID, err := h.Svc.AddLink(id, userID, h.optionalIfMatch(c, id), toModelLinks([]FirmwareLinkDTO{in})[0])
+	if err != nil {
+		h.respondRelationError(c, id, err)
+		return
+	}
+	setReleaseETag(c, out)
+	c.Header("Location", fmt.Sprintf("/api/releases/%d/links/%d", id, newID))
+	c.JSON(http.StatusCreated, findPublicLink(out, newID))
+}
+
+// addUploadedLink envia o arquivo ao file-server e cria o link com ele,
+// como o upload do Create: campos "file" (obrigatório), "signature" ou
+// "manifest", "dir", "module", "description", "kind" e "hardwareRevision".
+// A assinatura é conferida com as chaves do produto do release antes do
+// envio; é assim que um firmware entra num release já criado.
+func (h ReleaseHandler) addUploadedLink(c *gin.Context, id, userID uint) {
+	cur, err := h.Svc.Get(id)
+	if err != nil {
+		respondSvcError(c, err)
+		return
+	}
+	ifMatch := h.optionalIfMatch(c, id)
+	if ifMatch != nil && *ifMatch != cur.LockVersion {
+		h.respondStale(c, id, service.ErrStaleRelease)
+		return
+	}
+	fh, err := c.FormFile("file")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "campo 'file' obrigatório no multipart"})
+		return
+	}
+	filename := filepath.Base(fh.Filename)
+	module := strings.TrimSpace(c.PostForm("module"))
+	if module == "" {
+		module = "default"
+	}
+	desc := strings.TrimSpace(c.PostForm("description"))
+	if desc == "" {
+		desc = "Firmware"
+	}
+
+	f, err := fh.Open()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao abrir arquivo"})
+		return
+	}
+	defer f.Close()
+
+	keyID, ok := h.verifyUpload(c, cur.ProductID, cur.ProductName, filename, f)
+	if !ok {
+		return
+	}
+	up, err := h.davPut(c.Request.Context(), filename, c.PostForm("dir"), f)
+	if err != nil {
+		c.JSON(http.StatusBadGateway, gin.H{"error": "upload falhou: " + err.Error()})
+		return
+	}
+
+	out, newID, err := h.Svc.AddLink(id, userID, ifMatch, models.FirmwareLink{
+		Module:           module,
+		Description:      desc,
+		URL:              up.URL,
+		Kind:             models.LinkKind(strings.TrimSpace(c.PostForm("kind"))), // vazio = deduzido do arquivo
+		HardwareRevision: strings.TrimSpace(c.PostForm("hardwareRevision")),
+		SHA256:           up.SHA256,
+		SizeBytes:        up.Size,
+		MIMEType:         up.MIMEType,
+		OriginalFilename: up.Filename,
+		SignatureKeyID:   keyID,
+	})
+	if err != nil {
+		h.respondRelationError(c, id, err)
+		return
+	}
+	setReleaseETag(c, out)
+	c.Header("Location", fmt.Sprintf("/api/releases/%d/links/%d", id, newID))
+	c.JSON(http.StatusCreated, findPublicLink(out, newID))
+}
+
+// PUT /api/releases/:id/links/:linkId
+func (h ReleaseHandler) UpdateLink(c *gin.Context) {
+	id, linkID, userID, ok := relationTarget(c, "linkId")
+	if !ok {
+		return
+	}
+	var in FirmwareLinkDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !validLinkURL(c, in.URL) {
+		return
+	}
+	out, err := h.Svc.UpdateLink(id, linkID, userID, h.optionalIfMatch(c, id), toModelLinks([]FirmwareLinkDTO{in})[0])
+	if err != nil {
+		h.respondRelationError(c, id, err)
+		return
+	}
+	setReleaseETag(c, out)
+	c.JSON(http.StatusOK, findPublicLink(out, linkID))
+}
+
+// DELETE /api/releases/:id/links/:linkId
+func (h ReleaseHandler) DeleteLink(c *gin.Context) {
+	id, linkID, userID, ok := relationTarget(c, "linkId")
+	if !ok {
+		return
+	}
+	out, err := h.Svc.DeleteLink(id, linkID, userID, h.optionalIfMatch(c, id))
+	if err != nil {
+		h.respondRelationError(c, id, err)
+		return
+	}
+	setReleaseETag(c, out)
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,134 @@
+// internal/http/handlers/release_revision.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+type ReleaseRevisionPublic struct {
+	Revision  int         `json:"revision"`
+	Note      string      `json:"note,omitempty"`
+	Author    *UserPublic `json:"author,omitempty"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+type ReleaseRevisionDetail struct {
+	ReleaseRevisionPublic
+	Release ReleaseResponse `json:"release"`
+}
+
+type RevisionDiffResponse struct {
+	From int         `json:"from"`
+	To   int         `json:"to"`
+	Diff ReleaseDiff `json:"diff"`
+}
+
+// revisão inexistente responde 404 próprio; o resto segue respondSvcError
+func respondRevisionError(c *gin.Context, err error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "revisão não encontrada"})
+		return
+	}
+	respondSvcError(c, err)
+}
+
+func paramRev(c *gin.Context, name string) (int, bool) {
+	n, err := strconv.Atoi(c.Param(name))
+	if err != nil || n <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "revisão inválida"})
+		return 0, false
+	}
+	return n, true
+}
+
+// GET /api/releases/:id/revisions
+func (h ReleaseHandler) ListRevisions(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	list, err := h.Svc.ListRevisions(uint(id))
+	if err != nil {
+		respondSvcError(c, err)
+		return
+	}
+	resp := make([]ReleaseRevisionPublic, 0, len(list))
+	for _, rv := range list {
+		resp = append(resp, ReleaseRevisionPublic{
+			Revision: rv.Revision, Note: rv.Note, Author: toPublicUser(rv.Author), CreatedAt: rv.CreatedAt,
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// GET /api/releases/:id/revisions/:rev
+func (h ReleaseHandler) GetRevision(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	rev, ok := paramRev(c, "rev")
+	if !ok {
+		return
+	}
+	rv, rel, err := h.Svc.Revision(uint(id), rev)
+	if err != nil {
+		respondRevisionError(c, err)
+		return
+	}
+	resp := toReleaseResponse(rel)
+	resp.CreatedAt, resp.UpdatedAt = rv.CreatedAt, rv.CreatedAt
+	c.JSON(http.StatusOK, ReleaseRevisionDetail{
+		ReleaseRevisionPublic: ReleaseRevisionPublic{
+			Revision: rv.Revision, Note: rv.Note, Author: toPublicUser(rv.Author), CreatedAt: rv.CreatedAt,
+		},
+		Release: resp,
+	})
+}
+
+// GET /api/releases/:id/revisions/:rev/diff/:otherRev
+func (h ReleaseHandler) DiffRevisions(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	from, ok := paramRev(c, "rev")
+	if !ok {
+		return
+	}
+	to, ok := paramRev(c, "otherRev")
+	if !ok {
+		return
+	}
+	_, a, err := h.Svc.Revision(uint(id), from)
+	if err != nil {
+		respondRevisionError(c, err)
+		return
+	}
+	_, b, err := h.Svc.Revision(uint(id), to)
+	if err != nil {
+		respondRevisionError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, RevisionDiffResponse{
+		From: from, To: to,
+		Diff: diffReleases(toReleaseResponse(a), toReleaseResponse(b)),
+	})
+}
+
+// POST /api/releases/:id/revisions/:rev/restore
+func (h ReleaseHandler) RestoreRevision(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	rev, ok := paramRev(c, "rev")
+	if !ok {
+		return
+	}
+	userID, ok := ctxUserID(c)
+	if !ok {
+		return
+	}
+	out, err := h.Svc.RestoreRevision(uint(id), rev, userID)
+	if err != nil {
+		respondRevisionError(c, err)
+		return
+	}
+	setReleaseETag(c, out)
+	c.JSON(http.StatusOK, toReleaseResponse(out))
+}
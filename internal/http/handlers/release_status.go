@@ -0,0 +1,66 @@
+// internal/http/handlers/release_status.go
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+)
+
+type StatusChangeDTO struct {
+	Status string `json:"status" binding:"required"`
+	Reason string `json:"reason" binding:"required"`
+}
+
+type StatusTransitionPublic struct {
+	ID         uint        `json:"id"`
+	FromStatus string      `json:"fromStatus,omitempty"`
+	ToStatus   string      `json:"toStatus"`
+	Reason     string      `json:"reason"`
+	ChangedBy  *UserPublic `json:"changedBy,omitempty"`
+	CreatedAt  time.Time   `json:"createdAt"`
+}
+
+// POST /api/releases/:id/status  {status, reason}
+func (h ReleaseHandler) ChangeStatus(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	var in StatusChangeDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userID, ok := ctxUserID(c)
+	if !ok {
+		return
+	}
+
+	out, err := h.Svc.TransitionStatus(uint(id), models.FirmwareStatus(in.Status), in.Reason, userID, ctxRole(c))
+	if err != nil {
+		respondSvcError(c, err)
+		return
+	}
+	setReleaseETag(c, out)
+	c.JSON(http.StatusOK, toReleaseResponse(out))
+}
+
+// GET /api/releases/:id/status/history
+func (h ReleaseHandler) StatusHistory(c *gin.Context) {
+	id, _ := strconv.Atoi(c.Param("id"))
+	list, err := h.Svc.StatusHistory(uint(id))
+	if err != nil {
+		respondSvcError(c, err)
+		return
+	}
+	resp := make([]StatusTransitionPublic, 0, len(list))
+	for _, t := range list {
+		resp = append(resp, StatusTransitionPublic{
+			ID: t.ID, FromStatus: string(t.FromStatus), ToStatus: string(t.ToStatus),
+			Reason: t.Reason, ChangedBy: toPublicUser(t.ChangedBy), CreatedAt: t.CreatedAt,
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,55 @@
+// internal/http/handlers/release_tags.go
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TagsDTO struct {
+	Tags []string `json:"tags" binding:"required"` // [] remove todas
+}
+
+type TagCountPublic struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// PUT /api/releases/:id/tags  {tags: [...]}
+// Tags são rótulos: não exigem If-Match, mas a troca gera revisão.
+func (h ReleaseHandler) SetTags(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	var in TagsDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	userID, ok := ctxUserID(c)
+	if !ok {
+		return
+	}
+	out, err := h.Svc.SetTags(id, userID, in.Tags)
+	if err != nil {
+		respondSvcError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"tags": toPublicTags(out.Tags)})
+}
+
+// GET /api/tags?product=  (tags com o número de releases que as usam)
+func (h ReleaseHandler) ListTags(c *gin.Context) {
+	list, err := h.Svc.ListTags(c.Query("product"))
+	if err != nil {
+		respondSvcError(c, err)
+		return
+	}
+	resp := make([]TagCountPublic, 0, len(list))
+	for _, t := range list {
+		resp = append(resp, TagCountPublic{Name: t.Name, Count: t.Count})
+	}
+	c.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,122 @@
+// internal/http/handlers/release_translation.go
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/service"
+)
+
+type EntryTranslationDTO struct {
+	EntryID     uint   `json:"entryId" binding:"required"`
+	Observation string `json:"observation"`
+}
+
+type TranslationDTO struct {
+	ImportantNote string                `json:"importantNote"`
+	OTAObs        string                `json:"otaObs"`
+	Entries       []EntryTranslationDTO `json:"entries"`
+}
+
+type TranslationGapsPublic struct {
+	ImportantNote bool   `json:"importantNote"`
+	OTAObs        bool   `json:"otaObs"`
+	EntryIDs      []uint `json:"entryIds"`
+}
+
+type TranslationPublic struct {
+	Lang          string                `json:"lang"`
+	ImportantNote string                `json:"importantNote,omitempty"`
+	OTAObs        string                `json:"otaObs,omitempty"`
+	Entries       []EntryTranslationDTO `json:"entries"`
+	Missing       TranslationGapsPublic `json:"missing"`
+	Complete      bool                  `json:"complete"`
+}
+
+func toPublicTranslation(t *service.LanguageTranslation) TranslationPublic {
+	out := TranslationPublic{
+		Lang: t.Lang, ImportantNote: t.ImportantNote, OTAObs: t.OTAObs,
+		Entries: make([]EntryTranslationDTO, 0, len(t.Entries)),
+		Missing: TranslationGapsPublic{
+			ImportantNote: t.Missing.ImportantNote,
+			OTAObs:        t.Missing.OTAObs,
+			EntryIDs:      append([]uint{}, t.Missing.EntryIDs...),
+		},
+		Complete: t.Missing.Complete(),
+	}
+	for _, e := range t.Entries {
+		out.Entries = append(out.Entries, EntryTranslationDTO{EntryID: e.EntryID, Observation: e.Observation})
+	}
+	return out
+}
+
+// localize aplica o idioma pedido (?lang= tem prioridade sobre
+// Accept-Language) e devolve o idioma usado e quais releases ficaram com
+// algum texto no original.
+func (h ReleaseHandler) localize(c *gin.Context, list ...*models.Release) (string, map[uint]bool, error) {
+	if h.I18n == nil {
+		return "", nil, nil
+	}
+	lang := h.I18n.Languages().Pick(c.Query("lang"), c.GetHeader("Accept-Language"))
+	c.Header("Vary", "Accept-Language")
+	c.Header("Content-Language", lang)
+	missing, err := h.I18n.Localize(list, lang)
+	return lang, missing, err
+}
+
+// GET /api/releases/:id/translations
+func (h ReleaseHandler) Translations(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	list, err := h.I18n.Translations(id)
+	if err != nil {
+		respondSvcError(c, err)
+		return
+	}
+	resp := make([]TranslationPublic, 0, len(list))
+	for i := range list {
+		resp = append(resp, toPublicTranslation(&list[i]))
+	}
+	c.JSON(http.StatusOK, gin.H{"sourceLang": h.I18n.Languages().Source, "translations": resp})
+}
+
+// PUT /api/releases/:id/translations/:lang  (troca todas as traduções do idioma)
+func (h ReleaseHandler) SaveTranslation(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	var in TranslationDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	lt := service.LanguageTranslation{Lang: c.Param("lang"), ImportantNote: in.ImportantNote, OTAObs: in.OTAObs}
+	for _, e := range in.Entries {
+		lt.Entries = append(lt.Entries, service.EntryTranslation{EntryID: e.EntryID, Observation: e.Observation})
+	}
+	out, err := h.I18n.SaveTranslation(id, lt)
+	if err != nil {
+		respondSvcError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, toPublicTranslation(out))
+}
+
+// DELETE /api/releases/:id/translations/:lang
+func (h ReleaseHandler) DeleteTranslation(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	if err := h.I18n.DeleteTranslation(id, c.Param("lang")); err != nil {
+		respondSvcError(c, err)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,83 @@
+// internal/http/handlers/release_trash.go
+package handlers
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type TrashedReleasePublic struct {
+	ID          uint                `json:"id"`
+	Version     string              `json:"version"`
+	ProductName string              `json:"productName"`
+	Status      string              `json:"status"`
+	Links       []ReleaseLinkPublic `json:"links"`
+	DeletedBy   *UserPublic         `json:"deletedBy,omitempty"`
+	DeletedAt   time.Time           `json:"deletedAt"`
+	PurgeAt     time.Time           `json:"purgeAt"`
+}
+
+// GET /api/releases/trash
+func (h ReleaseHandler) ListTrash(c *gin.Context) {
+	list, err := h.Svc.ListTrash()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	resp := make([]TrashedReleasePublic, 0, len(list))
+	for _, r := range list {
+		resp = append(resp, TrashedReleasePublic{
+			ID: r.ID, Version: r.Version, ProductName: r.ProductName, Status: string(r.Status),
+			Links:     toPublicLinks(r.Links),
+			DeletedBy: toPublicUser(r.DeletedBy),
+			DeletedAt: r.DeletedAt.Time,
+			PurgeAt:   r.DeletedAt.Time.Add(h.TrashRetention),
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// POST /api/releases/:id/restore
+func (h ReleaseHandler) RestoreFromTrash(c *gin.Context) {
+	id, ok := paramID(c, "id")
+	if !ok {
+		return
+	}
+	userID, ok := ctxUserID(c)
+	if !ok {
+		return
+	}
+	out, err := h.Svc.RestoreFromTrash(id, userID)
+	if err != nil {
+		respondSvcError(c, err)
+		return
+	}
+	setReleaseETag(c, out)
+	c.JSON(http.StatusOK, toReleaseResponse(out))
+}
+
+// RunTrashPurge apaga de vez, a cada intervalo, os releases cuja retenção
+// expirou (banco + arquivos no file-server). Bloqueia até ctx terminar.
+func (h ReleaseHandler) RunTrashPurge(ctx context.Context, every time.Duration) {
+	t := time.NewTicker(every)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			n, err := h.Svc.PurgeExpired(h.TrashRetention, func(u string) error {
+				return h.deleteOwnedFile(ctx, u)
+			})
+			if err != nil {
+				log.Printf("lixeira: purga falhou: %v", err)
+			} else if n > 0 {
+				log.Printf("lixeira: %d release(s) apagado(s) definitivamente", n)
+			}
+		}
+	}
+}
@@ -0,0 +1,169 @@
+// internal/http/handlers/signing_key.go
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/service"
+)
+
+type SigningKeyHandler struct {
+	Svc *service.SigningService
+}
+
+type SigningKeyDTO struct {
+	Name      string `json:"name" binding:"required"`
+	PublicKey string `json:"publicKey" binding:"required"` // PEM "PUBLIC KEY" (ed25519 ou ECDSA P-256)
+}
+
+type SigningKeyPublic struct {
+	ID        uint      `json:"id"`
+	ProductID uint      `json:"productId"`
+	Name      string    `json:"name"`
+	KeyID     string    `json:"keyId"`
+	Algorithm string    `json:"algorithm"`
+	PublicKey string    `json:"publicKey"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func toPublicSigningKey(k *models.SigningKey) SigningKeyPublic {
+	return SigningKeyPublic{
+		ID: k.ID, ProductID: k.ProductID, Name: k.Name,
+		KeyID: k.KeyID, Algorithm: k.Algorithm, PublicKey: k.PublicKey, CreatedAt: k.CreatedAt,
+	}
+}
+
+// GET /api/products/:id/signing-keys  (chaves públicas: quem baixa também confere)
+func (h SigningKeyHandler) List(c *gin.Context) {
+	list, err := h.Svc.List(c.Param("id"))
+	if err != nil {
+		respondCatalogError(c, err, "produto")
+		return
+	}
+	resp := make([]SigningKeyPublic, 0, len(list))
+	for i := range list {
+		resp = append(resp, toPublicSigningKey(&list[i]))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// POST /api/products/:id/signing-keys
+func (h SigningKeyHandler) Create(c *gin.Context) {
+	var in SigningKeyDTO
+	if err := c.ShouldBindJSON(&in); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	out, err := h.Svc.Create(c.Param("id"), service.SigningKeyInput{Name: in.Name, PublicKey: in.PublicKey})
+	if err != nil {
+		respondCatalogError(c, err, "chave de assinatura")
+		return
+	}
+	c.JSON(http.StatusCreated, toPublicSigningKey(out))
+}
+
+// DELETE /api/products/:id/signing-keys/:keyId
+func (h SigningKeyHandler) Delete(c *gin.Context) {
+	id, ok := paramID(c, "keyId")
+	if !ok {
+		return
+	}
+	if err := h.Svc.Delete(c.Param("id"), id); err != nil {
+		respondCatalogError(c, err, "chave de assinatura")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+const (
+	// limite das partes "signature" e "manifest" do multipart
+	maxSignaturePart = 64 << 10
+	// arquivos até este tamanho ficam em memória na verificação, para
+	// aceitar Ed25519 puro; acima, só Ed25519ph ou manifesto
+	maxBufferedUpload = 64 << 20
+)
+
+// formPart lê um campo do multipart enviado como arquivo ou como texto.
+func formPart(c *gin.Context, name string) ([]byte, error) {
+	fh, err := c.FormFile(name)
+	if err != nil {
+		return []byte(c.PostForm(name)), nil
+	}
+	if fh.Size > maxSignaturePart {
+		return nil, fmt.Errorf("parte %q maior que %d bytes", name, maxSignaturePart)
+	}
+	f, err := fh.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return io.ReadAll(io.LimitReader(f, maxSignaturePart))
+}
+
+// verifyUpload confere a assinatura do arquivo antes de enviá-lo ao
+// file-server e devolve o KeyID da chave que a validou. O arquivo volta
+// ao início para o envio. Em caso de erro a resposta já foi escrita.
+func (h ReleaseHandler) verifyUpload(c *gin.Context, productID *uint, productName, filename string, f multipart.File) (string, bool) {
+	pid, err := h.Svc.ResolveProductID(productID, productName)
+	if err != nil {
+		respondSvcError(c, err)
+		return "", false
+	}
+	sig, err := formPart(c, "signature")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return "", false
+	}
+	manifest, err := formPart(c, "manifest")
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return "", false
+	}
+	size, err := f.Seek(0, io.SeekEnd)
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao ler arquivo"})
+		return "", false
+	}
+	h256, h512 := sha256.New(), sha512.New()
+	w := io.MultiWriter(h256, h512)
+	// só a assinatura destacada pode ser Ed25519 puro sobre o arquivo
+	var content *bytes.Buffer
+	if len(bytes.TrimSpace(manifest)) == 0 && size <= maxBufferedUpload {
+		content = bytes.NewBuffer(make([]byte, 0, size))
+		w = io.MultiWriter(h256, h512, content)
+	}
+	n, err := io.Copy(w, f)
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "falha ao ler arquivo"})
+		return "", false
+	}
+	up := service.SignedUpload{
+		Filename: filename, SHA256: h256.Sum(nil), SHA512: h512.Sum(nil), Size: n,
+		Signature: bytes.TrimSpace(sig), Manifest: bytes.TrimSpace(manifest),
+	}
+	if content != nil {
+		up.Content = content.Bytes()
+	}
+	key, err := h.Signing.VerifyUpload(pid, up)
+	if err != nil {
+		respondSvcError(c, err)
+		return "", false
+	}
+	return key.KeyID, true
+}
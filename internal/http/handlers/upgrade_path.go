@@ -0,0 +1,69 @@
+// internal/http/handlers/upgrade_path.go
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/service"
+)
+
+type UpgradeStepPublic struct {
+	ID              uint                `json:"id"`
+	Version         string              `json:"version"`
+	PreviousVersion string              `json:"previousVersion"`
+	OTA             bool                `json:"ota"`
+	OTAObs          string              `json:"otaObs,omitempty"`
+	ReleaseDate     time.Time           `json:"releaseDate"`
+	Status          string              `json:"status"`
+	Links           []ReleaseLinkPublic `json:"links"`
+}
+
+type UpgradePathResponse struct {
+	Product string                 `json:"product"`
+	From    string                 `json:"from"`
+	To      string                 `json:"to"`
+	Steps   []UpgradeStepPublic    `json:"steps"`
+	Issues  []service.UpgradeIssue `json:"issues"`
+}
+
+// GET /api/releases/upgrade-path?product=X&from=A&to=B
+func (h ReleaseHandler) UpgradePath(c *gin.Context) {
+	product, from, to := c.Query("product"), c.Query("from"), c.Query("to")
+
+	p, err := h.Svc.UpgradePath(product, from, to)
+	if err != nil {
+		var pe *service.UpgradePathError
+		if errors.As(err, &pe) {
+			st := http.StatusUnprocessableEntity
+			if pe.Code == service.UpgradeIssueNotFound {
+				st = http.StatusNotFound
+			}
+			c.JSON(st, gin.H{"error": pe.Message, "code": pe.Code, "version": pe.Version})
+			return
+		}
+		respondSvcError(c, err)
+		return
+	}
+
+	resp := UpgradePathResponse{
+		Product: product, From: from, To: to,
+		Steps:  make([]UpgradeStepPublic, 0, len(p.Steps)),
+		Issues: p.Issues,
+	}
+	if resp.Issues == nil {
+		resp.Issues = []service.UpgradeIssue{}
+	}
+	for _, r := range p.Steps {
+		resp.Steps = append(resp.Steps, UpgradeStepPublic{
+			ID: r.ID, Version: r.Version, PreviousVersion: r.PreviousVersion,
+			OTA: r.OTA, OTAObs: r.OTAObs, ReleaseDate: r.ReleaseDate,
+			Status: string(r.Status),
+			Links:  toPublicLinks(r.Links),
+		})
+	}
+	c.JSON(http.StatusOK, resp)
+}
@@ -1,6 +1,7 @@
 package router
 
 import (
+	"context"
 	"os"
 	"strconv"
 	"strings"
@@ -11,13 +12,16 @@ import (
 	"gorm.io/gorm"
 
 	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/http/handlers"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/i18n"
 	middleware "github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/http/midleware"
 	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/repository"
 	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/service"
 )
 
-func Setup(db *gorm.DB, jwtSecret string) *gin.Engine {
-    r := gin.Default()
+// Setup monta as rotas. jobs inicia as rotinas em segundo plano (purga da
+// lixeira) e fica com quem chama, que decide quando e com qual contexto.
+func Setup(db *gorm.DB, jwtSecret string) (r *gin.Engine, jobs func(ctx context.Context)) {
+    r = gin.Default()
 
     // aceita uploads grandes (ex.: até 512 MiB)
     r.MaxMultipartMemory = 512 << 20 // 512 MiB
@@ -27,9 +31,9 @@ func Setup(db *gorm.DB, jwtSecret string) *gin.Engine {
         AllowOrigins:     []string{"http://localhost:5173",
     "https://changelog.intelbras-cve-pro.com.br",
     "https://doc.intelbras-cve-pro.com.br"},
-        AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-        AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Accept"},
-        ExposeHeaders:    []string{"Content-Length"},
+        AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+        AllowHeaders:     []string{"Origin", "Content-Type", "Authorization", "Accept", "Accept-Language", "If-Match"},
+        ExposeHeaders:    []string{"Content-Length", "ETag", "Content-Language"},
         AllowCredentials: true,
         MaxAge:           12 * time.Hour,
     }))
@@ -37,9 +41,29 @@ func Setup(db *gorm.DB, jwtSecret string) *gin.Engine {
     // repos
     relRepo := repository.NewReleaseRepository(db)
     userRepo := repository.NewUserRepository(db)
+    prodRepo := repository.NewProductRepository(db)
+    apprRepo := repository.NewApprovalRepository(db)
+    clsRepo := repository.NewClassificationRepository(db)
+    trRepo := repository.NewTranslationRepository(db)
+    advRepo := repository.NewAdvisoryRepository(db)
+    kiRepo := repository.NewKnownIssueRepository(db)
+    tagRepo := repository.NewTagRepository(db)
+    intRepo := repository.NewIntegrityRepository(db)
+    sigRepo := repository.NewSigningKeyRepository(db)
 
     // services
-    relSvc := service.NewReleaseService(relRepo)
+    // RELEASE_REQUIRED_APPROVALS: aprovadores distintos para ir a producao
+    // (padrão 1; "0" desliga o fluxo e volta à publicação direta)
+    relSvc := service.NewReleaseService(relRepo, prodRepo, apprRepo, clsRepo, advRepo, tagRepo, envInt("RELEASE_REQUIRED_APPROVALS", 1))
+    prodSvc := service.NewProductService(prodRepo)
+    clsSvc := service.NewClassificationService(clsRepo)
+    advSvc := service.NewAdvisoryService(advRepo, prodRepo)
+    kiSvc := service.NewKnownIssueService(kiRepo, relRepo, prodRepo)
+    intSvc := service.NewIntegrityService(intRepo)
+    sigSvc := service.NewSigningService(sigRepo, prodRepo)
+    // I18N_SOURCE_LANG: idioma do texto gravado; I18N_LANGS: idiomas com tradução
+    langs := i18n.NewLanguages(envOr("I18N_SOURCE_LANG", "pt-BR"), i18n.ParseList(envOr("I18N_LANGS", "pt-BR,es,en")))
+    trSvc := service.NewTranslationService(relRepo, trRepo, langs)
     authSvc := service.NewAuthService(userRepo, jwtSecret)
     userSvc := service.NewUserService(userRepo)
 
@@ -48,14 +72,32 @@ func Setup(db *gorm.DB, jwtSecret string) *gin.Engine {
 
     rel := handlers.ReleaseHandler{
         Svc:            relSvc,
+        I18n:           trSvc,
+        Issues:         kiSvc,
+        Integrity:      intSvc,
+        Signing:        sigSvc,
         FilePublicBase: strings.TrimRight(envOr("FILE_PUBLIC_BASE", "https://files.seudominio.com/firmware"), "/"),
         FileServerBase: strings.TrimRight(envOr("FILE_SERVER_BASE", "https://files.seudominio.com/firmware"), "/"),
         FileServerUser: envOr("FILE_SERVER_USER", "uploader"),
         FileServerPass: envOr("FILE_SERVER_PASS", ""),
         HTTPTimeout:    envDur("HTTP_TIMEOUT", 120*time.Second), // aceita "120s" ou "120"
+        TrashRetention: envDur("TRASH_RETENTION", 30*24*time.Hour),
+    }
+
+    jobs = func(ctx context.Context) {
+        // purga da lixeira
+        go rel.RunTrashPurge(ctx, envDur("TRASH_PURGE_INTERVAL", time.Hour))
+        // conferência dos arquivos no file-server: periódica ("0" desliga o
+        // agendamento) e sob demanda via POST /api/releases/integrity/run
+        go rel.RunIntegrityChecks(ctx, envDur("INTEGRITY_CHECK_INTERVAL", 24*time.Hour))
     }
 
     user := handlers.UserHandler{Svc: userSvc}
+    prod := handlers.ProductHandler{Svc: prodSvc}
+    cls := handlers.ClassificationHandler{Svc: clsSvc}
+    adv := handlers.AdvisoryHandler{Svc: advSvc}
+    ki := handlers.KnownIssueHandler{Svc: kiSvc}
+    sk := handlers.SigningKeyHandler{Svc: sigSvc}
 
     // auth pública
     r.POST("/api/auth/login", auth.Login)
@@ -63,7 +105,27 @@ func Setup(db *gorm.DB, jwtSecret string) *gin.Engine {
 
     // público
     r.GET("/api/releases", rel.List)
+    r.GET("/api/releases/upgrade-path", rel.UpgradePath)
+    r.GET("/api/releases/changelog", rel.CumulativeChangelog)
     r.GET("/api/releases/:id", rel.Get)
+    r.GET("/api/releases/:id/diff/:otherId", rel.Diff)
+    r.GET("/api/releases/:id/status/history", rel.StatusHistory)
+    r.GET("/api/releases/:id/known-issues", ki.ListByRelease)
+    r.GET("/api/releases/:id/links", rel.ListLinks)
+    r.GET("/api/releases/:id/links/:linkId/sha256", rel.LinkChecksum)
+    r.GET("/api/product-categories", prod.ListCategories)
+    r.GET("/api/classifications", cls.List)
+    r.GET("/api/tags", rel.ListTags)
+    r.GET("/api/products", prod.List)
+    r.GET("/api/products/:id", prod.Get)
+    r.GET("/api/products/:id/modules", prod.ListModules)
+    r.GET("/api/products/:id/compatibility", rel.Compatibility)
+    r.GET("/api/products/:id/releases/:version", rel.GetByProductVersion)
+    r.GET("/api/products/:id/advisories", adv.ListByProduct)
+    r.GET("/api/products/:id/known-issues", ki.ListByProduct)
+    r.GET("/api/products/:id/signing-keys", sk.List)
+    r.GET("/api/advisories", adv.List)
+    r.GET("/api/advisories/:id", adv.Get)
 
     // protegido
     protected := r.Group("/api")
@@ -72,18 +134,105 @@ func Setup(db *gorm.DB, jwtSecret string) *gin.Engine {
         ed := protected.Group("/releases")
         ed.Use(middleware.RequireRole("admin", "editor"))
 
-        // Create aceita JSON ou multipart (campo "data" + "file"), e usa DAV PUT
+        // Create aceita JSON ou multipart (campo "data" + "file"), e usa DAV PUT;
+        // o arquivo precisa vir com "signature" ou "manifest" (ver SigningService)
         ed.POST("", rel.Create)
         ed.PUT("/:id", rel.Update)
+        ed.PATCH("/:id", rel.Patch) // JSON Merge Patch: só os campos enviados mudam
+
+        // Sub-recursos: edição item a item (IDs estáveis)
+        ed.POST("/:id/entries", rel.AddEntry)
+        ed.PUT("/:id/entries/order", rel.ReorderEntries)
+        ed.PUT("/:id/entries/:entryId", rel.UpdateEntry)
+        ed.DELETE("/:id/entries/:entryId", rel.DeleteEntry)
+        ed.POST("/:id/modules", rel.AddModule)
+        ed.PUT("/:id/modules/:moduleId", rel.UpdateModule)
+        ed.DELETE("/:id/modules/:moduleId", rel.DeleteModule)
+        ed.POST("/:id/links", rel.AddLink) // JSON (URL) ou multipart (arquivo assinado)
+        ed.PUT("/:id/links/:linkId", rel.UpdateLink)
+        ed.DELETE("/:id/links/:linkId", rel.DeleteLink)
+        ed.PUT("/:id/tags", rel.SetTags)
+
+        // Mudança de status segue a máquina de estados (permissão por transição)
+        ed.POST("/:id/status", rel.ChangeStatus)
+
+        // Aprovação antes de produção: editor submete, aprovador dá parecer
+        ed.POST("/:id/approval", rel.SubmitForApproval)
+        appr := protected.Group("/releases/:id/approval")
+        appr.Use(middleware.RequireRole("admin", "approver"))
+        appr.POST("/approve", rel.Approve)
+        appr.POST("/reject", rel.Reject)
+        protected.GET("/releases/:id/approval", rel.Approval)
 
-        // Apaga release; antes tenta DAV DELETE para cada link do release
+        // Histórico de edições
+        protected.GET("/releases/:id/revisions", rel.ListRevisions)
+        protected.GET("/releases/:id/revisions/:rev", rel.GetRevision)
+        protected.GET("/releases/:id/revisions/:rev/diff/:otherRev", rel.DiffRevisions)
+        ed.POST("/:id/revisions/:rev/restore", rel.RestoreRevision)
+
+        // Traduções dos textos (o original fica no próprio release)
+        protected.GET("/releases/:id/translations", rel.Translations)
+        ed.PUT("/:id/translations/:lang", rel.SaveTranslation)
+        ed.DELETE("/:id/translations/:lang", rel.DeleteTranslation)
+
+        // Problemas conhecidos: cadastrados no release onde apareceram
+        ed.POST("/:id/known-issues", ki.Create)
+        kn := protected.Group("/known-issues")
+        kn.Use(middleware.RequireRole("admin", "editor"))
+        kn.PUT("/:id", ki.Update)
+        kn.DELETE("/:id", ki.Delete)
+
+        // Apaga release (vai para a lixeira; arquivos saem só na purga)
         ed.DELETE("/:id", middleware.RequireRole("admin"), rel.Delete)
 
+        // Lixeira
+        ed.GET("/trash", middleware.RequireRole("admin"), rel.ListTrash)
+        ed.POST("/:id/restore", middleware.RequireRole("admin"), rel.RestoreFromTrash)
+
+        // Integridade dos arquivos no file-server (SHA-256 x upload)
+        ed.GET("/integrity", middleware.RequireRole("admin"), rel.IntegritySummary)
+        ed.POST("/integrity/run", middleware.RequireRole("admin"), rel.RunIntegrityCheck)
+        ed.GET("/integrity/run", middleware.RequireRole("admin"), rel.IntegrityRunStatus)
+        ed.GET("/:id/links/:linkId/verifications", middleware.RequireRole("admin"), rel.LinkVerifications)
+
         // Apagar um arquivo avulso do file-server (URL ou path em JSON)
         ed.DELETE("/file", middleware.RequireRole("admin"), rel.DeleteFile)
+
+        // catálogo de produtos: só admin altera
+        cat := protected.Group("/product-categories")
+        cat.Use(middleware.RequireRole("admin"))
+        cat.POST("", prod.CreateCategory)
+        cat.PUT("/:id", prod.UpdateCategory)
+        cat.DELETE("/:id", prod.DeleteCategory)
+
+        pr := protected.Group("/products")
+        pr.Use(middleware.RequireRole("admin"))
+        pr.POST("", prod.Create)
+        pr.PUT("/:id", prod.Update)
+        pr.DELETE("/:id", prod.Delete)
+        pr.POST("/:id/modules", prod.CreateModule)
+        pr.PUT("/:id/modules/:moduleId", prod.UpdateModule)
+        pr.DELETE("/:id/modules/:moduleId", prod.DeleteModule)
+        // chaves públicas dos fabricantes (assinatura obrigatória no upload)
+        pr.POST("/:id/signing-keys", sk.Create)
+        pr.DELETE("/:id/signing-keys/:keyId", sk.Delete)
+
+        // classificações das entradas de changelog: só admin altera
+        cl := protected.Group("/classifications")
+        cl.Use(middleware.RequireRole("admin"))
+        cl.POST("", cls.Create)
+        cl.PUT("/:id", cls.Update)
+        cl.DELETE("/:id", cls.Delete)
+
+        // boletins de segurança (CVE/CVSS) citados pelas entradas "Segurança"
+        av := protected.Group("/advisories")
+        av.Use(middleware.RequireRole("admin", "editor"))
+        av.POST("", adv.Create)
+        av.PUT("/:id", adv.Update)
+        av.DELETE("/:id", middleware.RequireRole("admin"), adv.Delete)
     }
 
-    return r
+    return r, jobs
 }
 
 func envOr(k, def string) string {
@@ -93,6 +242,11 @@ func envOr(k, def string) string {
     return def
 }
 
+func envInt(k string, def int) int {
+    if n, err := strconv.Atoi(os.Getenv(k)); err == nil && n >= 0 { return n }
+    return def
+}
+
 // HTTP_TIMEOUT: "120s" OU "120" (segundos)
 func envDur(k string, def time.Duration) time.Duration {
     v := os.Getenv(k)
@@ -0,0 +1,155 @@
+// Package i18n escolhe o idioma das respostas a partir de Accept-Language
+// (RFC 9110, seção 12.5.4) ou de uma escolha explícita.
+package i18n
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Languages descreve o idioma dos textos originais e os idiomas para os
+// quais se espera tradução.
+type Languages struct {
+	Source    string
+	Supported []string // inclui Source
+}
+
+// NewLanguages normaliza a configuração e garante Source em Supported.
+func NewLanguages(source string, supported []string) Languages {
+	l := Languages{Source: Canonical(source)}
+	seen := map[string]bool{}
+	for _, t := range append([]string{l.Source}, supported...) {
+		t = Canonical(t)
+		if t != "" && !seen[t] {
+			seen[t] = true
+			l.Supported = append(l.Supported, t)
+		}
+	}
+	return l
+}
+
+// Targets são os idiomas que precisam de tradução (todos menos Source).
+func (l Languages) Targets() []string {
+	out := make([]string, 0, len(l.Supported))
+	for _, t := range l.Supported {
+		if t != l.Source {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// Pick escolhe o idioma: explicit (ex.: ?lang=) tem prioridade sobre
+// Accept-Language; sem correspondência, vale Source.
+func (l Languages) Pick(explicit, acceptLanguage string) string {
+	if explicit != "" {
+		if t, ok := Negotiate(explicit, l.Supported); ok {
+			return t
+		}
+		return l.Source
+	}
+	if t, ok := Negotiate(acceptLanguage, l.Supported); ok {
+		return t
+	}
+	return l.Source
+}
+
+// ParseList lê uma lista separada por vírgulas ("pt-BR, es ,en").
+func ParseList(s string) []string {
+	var out []string
+	for _, t := range strings.Split(s, ",") {
+		if t = Canonical(t); t != "" {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// Canonical normaliza a grafia da tag: "pt_br" -> "pt-BR", "EN" -> "en".
+func Canonical(tag string) string {
+	tag = strings.TrimSpace(strings.ReplaceAll(tag, "_", "-"))
+	if tag == "" {
+		return ""
+	}
+	parts := strings.Split(tag, "-")
+	for i, p := range parts {
+		switch {
+		case i == 0:
+			parts[i] = strings.ToLower(p)
+		case len(p) == 2:
+			parts[i] = strings.ToUpper(p) // região
+		case len(p) == 4:
+			parts[i] = strings.ToUpper(p[:1]) + strings.ToLower(p[1:]) // script
+		default:
+			parts[i] = strings.ToLower(p)
+		}
+	}
+	return strings.Join(parts, "-")
+}
+
+func primary(tag string) string {
+	if i := strings.IndexByte(tag, '-'); i >= 0 {
+		return tag[:i]
+	}
+	return tag
+}
+
+type weighted struct {
+	tag string
+	q   float64
+}
+
+// parse lê "es-AR,es;q=0.9,en;q=0.5" em ordem de preferência.
+// Itens com q=0 ou malformados são ignorados.
+func parse(header string) []weighted {
+	var out []weighted
+	for _, item := range strings.Split(header, ",") {
+		fields := strings.Split(item, ";")
+		tag := Canonical(fields[0])
+		if tag == "" {
+			continue
+		}
+		q := 1.0
+		for _, p := range fields[1:] {
+			k, v, ok := strings.Cut(strings.TrimSpace(p), "=")
+			if !ok || strings.TrimSpace(k) != "q" {
+				continue
+			}
+			f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil || f < 0 || f > 1 {
+				q = 0
+				break
+			}
+			q = f
+		}
+		if q > 0 {
+			out = append(out, weighted{tag, q})
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].q > out[j].q })
+	return out
+}
+
+// Negotiate devolve o idioma de available que melhor atende header.
+// Para cada faixa, em ordem de preferência, tenta a tag exata e depois o
+// idioma primário ("es-AR" aceita "es"; "pt" aceita "pt-BR"). "*" não
+// escolhe nada: fica a critério de quem chama.
+func Negotiate(header string, available []string) (string, bool) {
+	for _, w := range parse(header) {
+		if w.tag == "*" {
+			continue
+		}
+		for _, a := range available {
+			if strings.EqualFold(a, w.tag) {
+				return a, true
+			}
+		}
+		for _, a := range available {
+			if primary(strings.ToLower(a)) == primary(w.tag) {
+				return a, true
+			}
+		}
+	}
+	return "", false
+}
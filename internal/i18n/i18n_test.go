@@ -0,0 +1,69 @@
+package i18n_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/i18n"
+)
+
+var langs = i18n.NewLanguages("pt-BR", []string{"es", "en"})
+
+func TestNegotiate(t *testing.T) {
+	cases := []struct {
+		header string
+		want   string
+		ok     bool
+	}{
+		{"es", "es", true},
+		{"es-AR,es;q=0.9,en;q=0.8", "es", true},
+		{"fr-FR, en;q=0.5", "en", true},
+		{"en;q=0.3, es;q=0.7", "es", true},
+		{"pt", "pt-BR", true},
+		{"PT-br", "pt-BR", true},
+		{"de, fr;q=0.9", "", false},
+		{"*", "", false},
+		{"es;q=0, en", "en", true},
+		{"es;q=abc, en;q=0.1", "en", true},
+		{"", "", false},
+	}
+	for _, tc := range cases {
+		got, ok := i18n.Negotiate(tc.header, langs.Supported)
+		if got != tc.want || ok != tc.ok {
+			t.Fatalf("Negotiate(%q) = %q,%v; esperava %q,%v", tc.header, got, ok, tc.want, tc.ok)
+		}
+	}
+}
+
+func TestPick_ExplicitWins(t *testing.T) {
+	if got := langs.Pick("en", "es"); got != "en" {
+		t.Fatalf("got %q", got)
+	}
+	if got := langs.Pick("", "es-MX"); got != "es" {
+		t.Fatalf("got %q", got)
+	}
+	if got := langs.Pick("ja", "es"); got != "pt-BR" {
+		t.Fatalf("idioma explícito sem suporte deveria cair no original, veio %q", got)
+	}
+	if got := langs.Pick("", ""); got != "pt-BR" {
+		t.Fatalf("got %q", got)
+	}
+}
+
+func TestLanguages(t *testing.T) {
+	l := i18n.NewLanguages("pt_br", i18n.ParseList("en, ES ,pt-BR,,en"))
+	if want := []string{"pt-BR", "en", "es"}; !reflect.DeepEqual(l.Supported, want) {
+		t.Fatalf("Supported = %v, esperava %v", l.Supported, want)
+	}
+	if want := []string{"en", "es"}; !reflect.DeepEqual(l.Targets(), want) {
+		t.Fatalf("Targets = %v, esperava %v", l.Targets(), want)
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	for in, want := range map[string]string{"pt_br": "pt-BR", "EN": "en", "zh-hant-tw": "zh-Hant-TW", " es-419 ": "es-419"} {
+		if got := i18n.Canonical(in); got != want {
+			t.Fatalf("Canonical(%q) = %q, esperava %q", in, got, want)
+		}
+	}
+}
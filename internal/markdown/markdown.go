@@ -0,0 +1,191 @@
+// Package markdown converte os textos dos releases (gravados em Markdown)
+// para HTML seguro e para texto puro.
+package markdown
+
+import (
+	"bufio"
+	"bytes"
+	"html"
+	"strconv"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	east "github.com/yuin/goldmark/extension/ast"
+	ghtml "github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
+)
+
+// HTML bruto no Markdown não é repassado pelo goldmark (sem WithUnsafe) e
+// a saída ainda passa pela política UGC do bluemonday.
+var (
+	md     = goldmark.New(goldmark.WithExtensions(extension.GFM))
+	policy = newPolicy()
+)
+
+func newPolicy() *bluemonday.Policy {
+	p := bluemonday.UGCPolicy()
+	p.AllowURLSchemes("http", "https", "mailto")
+	p.RequireNoFollowOnLinks(true)
+	p.AddTargetBlankToFullyQualifiedLinks(true)
+	return p
+}
+
+// ToHTML renderiza src e remove qualquer coisa que possa executar script.
+func ToHTML(src string) string {
+	if strings.TrimSpace(src) == "" {
+		return ""
+	}
+	var buf bytes.Buffer
+	if err := md.Convert([]byte(src), &buf); err != nil {
+		// o goldmark só falha ao escrever no buffer; por garantia, devolve escapado
+		return policy.Sanitize("<p>" + src + "</p>")
+	}
+	return strings.TrimSpace(policy.Sanitize(buf.String()))
+}
+
+// ToText devolve o texto sem marcação, para telas de dispositivos: itens
+// de lista viram "- item" (ou "1. item") e links viram "texto (url)".
+func ToText(src string) string {
+	if strings.TrimSpace(src) == "" {
+		return ""
+	}
+	source := []byte(src)
+	doc := md.Parser().Parse(text.NewReader(source))
+
+	var b strings.Builder
+	w := &textWriter{b: &b, src: source}
+	w.block(doc, 0)
+	return strings.TrimSpace(b.String())
+}
+
+type textWriter struct {
+	b   *strings.Builder
+	src []byte
+}
+
+// block escreve um bloco e separa blocos com linha em branco (listas
+// ficam compactas).
+func (w *textWriter) block(n ast.Node, depth int) {
+	for c := n.FirstChild(); c != nil; c = c.NextSibling() {
+		switch c := c.(type) {
+		case *ast.List:
+			w.list(c, depth)
+			w.b.WriteString("\n")
+		case *ast.ThematicBreak:
+			w.b.WriteString("---\n\n")
+		case *ast.FencedCodeBlock, *ast.CodeBlock:
+			lines := c.Lines()
+			for i := 0; i < lines.Len(); i++ {
+				seg := lines.At(i)
+				w.b.Write(seg.Value(w.src))
+			}
+			w.b.WriteString("\n")
+		case *east.Table:
+			for row := c.FirstChild(); row != nil; row = row.NextSibling() {
+				var cells []string
+				for cell := row.FirstChild(); cell != nil; cell = cell.NextSibling() {
+					var cb strings.Builder
+					(&textWriter{b: &cb, src: w.src}).inline(cell)
+					cells = append(cells, strings.TrimSpace(cb.String()))
+				}
+				w.b.WriteString(strings.Join(cells, " | ") + "\n")
+			}
+			w.b.WriteString("\n")
+		default:
+			if c.Type() == ast.TypeBlock && c.HasChildren() && c.FirstChild().Type() == ast.TypeBlock {
+				w.block(c, depth) // ex.: citação
+				continue
+			}
+			w.inline(c)
+			w.b.WriteString("\n\n")
+		}
+	}
+}
+
+func (w *textWriter) list(l *ast.List, depth int) {
+	n := l.Start
+	if n == 0 {
+		n = 1
+	}
+	for item := l.FirstChild(); item != nil; item = item.NextSibling() {
+		w.b.WriteString(strings.Repeat("  ", depth))
+		if l.IsOrdered() {
+			w.b.WriteString(strconv.Itoa(n) + ". ")
+			n++
+		} else {
+			w.b.WriteString("- ")
+		}
+		w.listItem(item, depth)
+	}
+}
+
+func (w *textWriter) listItem(item ast.Node, depth int) {
+	for c := item.FirstChild(); c != nil; c = c.NextSibling() {
+		if l, ok := c.(*ast.List); ok {
+			w.b.WriteString("\n")
+			w.list(l, depth+1)
+			continue
+		}
+		if c != item.FirstChild() {
+			w.b.WriteString(" ")
+		}
+		w.inline(c)
+	}
+	if !strings.HasSuffix(w.b.String(), "\n") {
+		w.b.WriteString("\n")
+	}
+}
+
+func (w *textWriter) inline(n ast.Node) {
+	_ = ast.Walk(n, func(c ast.Node, entering bool) (ast.WalkStatus, error) {
+		switch c := c.(type) {
+		case *ast.Text:
+			if entering {
+				w.b.WriteString(unescape(c.Segment.Value(w.src)))
+				if c.SoftLineBreak() || c.HardLineBreak() {
+					w.b.WriteString("\n")
+				}
+			}
+		case *ast.String:
+			if entering {
+				w.b.Write(c.Value)
+			}
+		case *ast.CodeSpan:
+			if entering {
+				for t := c.FirstChild(); t != nil; t = t.NextSibling() {
+					if s, ok := t.(*ast.Text); ok {
+						w.b.Write(s.Segment.Value(w.src))
+					}
+				}
+				return ast.WalkSkipChildren, nil
+			}
+		case *ast.AutoLink:
+			if entering {
+				w.b.Write(c.URL(w.src))
+			}
+		case *ast.Link:
+			if !entering {
+				if dest := string(c.Destination); dest != "" {
+					w.b.WriteString(" (" + dest + ")")
+				}
+			}
+		case *ast.RawHTML:
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+// unescape tira as barras de escape ("\*") e resolve entidades ("&amp;")
+// de um trecho de texto. O writer do goldmark faz isso gerando HTML; o
+// resultado é desescapado de volta para texto puro.
+func unescape(v []byte) string {
+	var buf bytes.Buffer
+	bw := bufio.NewWriter(&buf)
+	ghtml.DefaultWriter.Write(bw, v)
+	_ = bw.Flush()
+	return html.UnescapeString(buf.String())
+}
@@ -0,0 +1,69 @@
+package markdown_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/markdown"
+)
+
+func TestToHTML_Renders(t *testing.T) {
+	got := markdown.ToHTML("Atualize **antes** de:\n\n- item 1\n- [manual](https://example.com/m.pdf)")
+	for _, want := range []string{
+		"<strong>antes</strong>",
+		"<li>item 1</li>",
+		`href="https://example.com/m.pdf"`,
+		`rel="nofollow noopener"`,
+	} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("esperava %q em:\n%s", want, got)
+		}
+	}
+}
+
+func TestToHTML_Sanitizes(t *testing.T) {
+	for _, src := range []string{
+		`<script>alert(1)</script>`,
+		`<img src=x onerror=alert(1)>`,
+		`[clique](javascript:alert(1))`,
+		`<a href="javascript:alert(1)">x</a>`,
+		"[x](data:text/html;base64,PHNjcmlwdD4=)",
+	} {
+		got := strings.ToLower(markdown.ToHTML(src))
+		for _, bad := range []string{"<script", "onerror", "javascript:", "data:text"} {
+			if strings.Contains(got, bad) {
+				t.Fatalf("%q gerou HTML inseguro: %s", src, got)
+			}
+		}
+	}
+}
+
+func TestToText(t *testing.T) {
+	src := "# Atenção\n\nAtualize **antes** de usar.\n\n1. um\n2. dois\n   - sub\n\nVeja [o manual](https://example.com/m.pdf) e `cfg`."
+	want := "Atenção\n\nAtualize antes de usar.\n\n1. um\n2. dois\n  - sub\n\nVeja o manual (https://example.com/m.pdf) e cfg."
+	if got := markdown.ToText(src); got != want {
+		t.Fatalf("got:\n%s\n\nesperava:\n%s", got, want)
+	}
+}
+
+func TestToText_EntitiesAndEscapes(t *testing.T) {
+	for _, tc := range []struct{ src, want string }{
+		{"Fish &amp; chips", "Fish & chips"},
+		{`\*not em\*`, "*not em*"},
+		{"&lt;b&gt; &#65; &#x42; &copy;", "<b> A B ©"},
+		{`\&amp;`, "&amp;"},
+		{"C:\\temp", `C:\temp`},
+		{"`&amp; \\*`", `&amp; \*`}, // código fica literal
+		{"- a &amp; b\n- \\# c", "- a & b\n- # c"},
+	} {
+		if got := markdown.ToText(tc.src); got != tc.want {
+			t.Errorf("ToText(%q) = %q, esperava %q", tc.src, got, tc.want)
+		}
+	}
+}
+
+func TestEmpty(t *testing.T) {
+	if markdown.ToHTML("  ") != "" || markdown.ToText("") != "" {
+		t.Fatal("texto vazio deve continuar vazio")
+	}
+}
@@ -0,0 +1,62 @@
+// Package mergepatch aplica JSON Merge Patch (RFC 7396).
+package mergepatch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidPatch: o corpo do patch não é JSON válido.
+var ErrInvalidPatch = errors.New("merge patch inválido")
+
+// Apply aplica patch sobre doc e devolve o documento resultante.
+// Membros com valor null são removidos, objetos são mesclados
+// recursivamente e qualquer outro valor (inclusive arrays) substitui o
+// original por inteiro.
+func Apply(doc, patch []byte) ([]byte, error) {
+	var d any
+	if len(bytes.TrimSpace(doc)) > 0 {
+		if err := decode(doc, &d); err != nil {
+			return nil, fmt.Errorf("documento inválido: %w", err)
+		}
+	}
+	var p any
+	if err := decode(patch, &p); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return json.Marshal(merge(d, p))
+}
+
+// decode preserva números como json.Number para não perder precisão.
+func decode(b []byte, v *any) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errors.New("conteúdo extra após o JSON")
+	}
+	return nil
+}
+
+func merge(target, patch any) any {
+	pm, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	tm, ok := target.(map[string]any)
+	if !ok {
+		tm = map[string]any{}
+	}
+	for k, v := range pm {
+		if v == nil {
+			delete(tm, k)
+			continue
+		}
+		tm[k] = merge(tm[k], v)
+	}
+	return tm
+}
@@ -0,0 +1,70 @@
+package mergepatch_test
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/mergepatch"
+)
+
+// Exemplos do apêndice A da RFC 7396.
+func TestApply_RFCExamples(t *testing.T) {
+	cases := []struct{ doc, patch, want string }{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":"b","b":"c"}`, `{"a":null}`, `{"b":"c"}`},
+		{`{"a":["b"]}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"c"}`, `{"a":["b"]}`, `{"a":["b"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":[{"b":"c"}]}`, `{"a":[1]}`, `{"a":[1]}`},
+		{`["a","b"]`, `["c","d"]`, `["c","d"]`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`{"a":"foo"}`, `null`, `null`},
+		{`{"a":"foo"}`, `"bar"`, `"bar"`},
+		{`{"e":null}`, `{"a":1}`, `{"a":1,"e":null}`},
+		{`[1,2]`, `{"a":"b","c":null}`, `{"a":"b"}`},
+		{`{}`, `{"a":{"bb":{"ccc":null}}}`, `{"a":{"bb":{}}}`},
+	}
+	for _, tc := range cases {
+		got, err := mergepatch.Apply([]byte(tc.doc), []byte(tc.patch))
+		if err != nil {
+			t.Fatalf("%s + %s: erro inesperado: %v", tc.doc, tc.patch, err)
+		}
+		if !jsonEqual(t, got, []byte(tc.want)) {
+			t.Fatalf("%s + %s = %s, esperava %s", tc.doc, tc.patch, got, tc.want)
+		}
+	}
+}
+
+func TestApply_KeepsLargeNumbers(t *testing.T) {
+	got, err := mergepatch.Apply([]byte(`{"id":9007199254740993}`), []byte(`{"ota":true}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"id":9007199254740993,"ota":true}`; string(got) != want {
+		t.Fatalf("got %s, esperava %s", got, want)
+	}
+}
+
+func TestApply_InvalidPatch(t *testing.T) {
+	for _, p := range []string{``, `{`, `{"a":1} {"b":2}`} {
+		if _, err := mergepatch.Apply([]byte(`{}`), []byte(p)); !errors.Is(err, mergepatch.ErrInvalidPatch) {
+			t.Fatalf("%q: esperava ErrInvalidPatch, veio %v", p, err)
+		}
+	}
+}
+
+func jsonEqual(t *testing.T, a, b []byte) bool {
+	t.Helper()
+	var x, y any
+	if err := json.Unmarshal(a, &x); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(b, &y); err != nil {
+		t.Fatal(err)
+	}
+	return reflect.DeepEqual(x, y)
+}
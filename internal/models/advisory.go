@@ -0,0 +1,25 @@
+// internal/models/advisory.go
+package models
+
+import "time"
+
+// SecurityAdvisory é o boletim estruturado de uma vulnerabilidade de um
+// produto. Entradas de changelog "Segurança" apontam para ele (AdvisoryID).
+type SecurityAdvisory struct {
+	ID          uint     `gorm:"primaryKey"`
+	ProductID   uint     `gorm:"not null;index"`
+	Product     *Product `gorm:"foreignKey:ProductID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	Identifier  string   `gorm:"size:40;not null;uniqueIndex"` // CVE-2025-12345 ou ID interno
+	Title       string   `gorm:"size:255;not null"`
+	Description string   `gorm:"type:text"`
+	CVSSVector  string   `gorm:"size:160"`
+	CVSSScore   float64  // score base (calculado do vetor, se houver)
+	Severity    string   `gorm:"size:10;index"` // none|low|medium|high|critical
+	// faixa afetada (inclusiva); AffectedFrom vazio = desde a primeira versão
+	AffectedFrom string `gorm:"size:32"`
+	AffectedTo   string `gorm:"size:32;not null"`
+	FixedVersion string `gorm:"size:32"` // vazio = ainda sem correção
+	DisclosedAt  *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
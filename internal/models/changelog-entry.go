@@ -1,8 +1,10 @@
 // internal/models/changelog_entry.go
 package models
 
+// EntryClassification guarda a Key de uma Classification cadastrada.
 type EntryClassification string
 
+// classificações padrão (ver DefaultClassifications)
 const (
 	ClassificationNovo       EntryClassification = "Novo"
 	ClassificationOtimizacao EntryClassification = "Otimização"
@@ -14,6 +16,10 @@ type ChangelogEntry struct {
 	ID             uint                `gorm:"primaryKey"`
 	ReleaseID      uint                `gorm:"index"`
 	ItemOrder      int                 // 1,2,3...
-	Classification EntryClassification `gorm:"size:16"`
+	Classification EntryClassification `gorm:"size:40"`
 	Observation    string              `gorm:"type:text"`
+	// só para entradas de Segurança: boletim com CVE, CVSS e versões afetadas
+	AdvisoryID   *uint                       `gorm:"index"`
+	Advisory     *SecurityAdvisory           `json:"-" gorm:"foreignKey:AdvisoryID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	Translations []ChangelogEntryTranslation `json:"-" gorm:"foreignKey:EntryID;constraint:OnDelete:CASCADE"`
 }
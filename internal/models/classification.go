@@ -0,0 +1,28 @@
+// internal/models/classification.go
+package models
+
+import "time"
+
+// Classification é uma classificação de entrada de changelog cadastrada
+// pelo admin. Key é o valor gravado em ChangelogEntry.Classification e não
+// muda depois de criada; os rótulos de exibição ficam em Labels.
+type Classification struct {
+	ID        uint              `gorm:"primaryKey"`
+	Key       string            `gorm:"size:40;not null;uniqueIndex"`
+	Labels    map[string]string `gorm:"serializer:json;type:text"` // idioma -> rótulo, ex.: {"pt-BR": "Correção", "en": "Fix"}
+	Color     string            `gorm:"size:7"`                    // "#RRGGBB"
+	SortOrder int               `gorm:"not null;default:0"`
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// DefaultClassifications é o conjunto criado na primeira migração
+// (antes eram constantes fixas).
+func DefaultClassifications() []Classification {
+	return []Classification{
+		{Key: string(ClassificationNovo), Labels: map[string]string{"pt-BR": "Novo", "en": "New"}, Color: "#2E7D32", SortOrder: 10},
+		{Key: string(ClassificationOtimizacao), Labels: map[string]string{"pt-BR": "Otimização", "en": "Improvement"}, Color: "#1565C0", SortOrder: 20},
+		{Key: string(ClassificationCorrecao), Labels: map[string]string{"pt-BR": "Correção", "en": "Fix"}, Color: "#EF6C00", SortOrder: 30},
+		{Key: string(ClassificationSeguranca), Labels: map[string]string{"pt-BR": "Segurança", "en": "Security"}, Color: "#C62828", SortOrder: 40},
+	}
+}
@@ -0,0 +1,40 @@
+// internal/models/known_issue.go
+package models
+
+import "time"
+
+type IssueSeverity string
+
+const (
+	IssueSeverityLow      IssueSeverity = "low"
+	IssueSeverityMedium   IssueSeverity = "medium"
+	IssueSeverityHigh     IssueSeverity = "high"
+	IssueSeverityCritical IssueSeverity = "critical"
+)
+
+func (s IssueSeverity) Valid() bool {
+	switch s {
+	case IssueSeverityLow, IssueSeverityMedium, IssueSeverityHigh, IssueSeverityCritical:
+		return true
+	default:
+		return false
+	}
+}
+
+// KnownIssue é um problema conhecido encontrado em um release (ReleaseID).
+// Vale para esse release e os seguintes do produto até o release que o
+// corrige (FixedInReleaseID), exclusive.
+type KnownIssue struct {
+	ID               uint          `gorm:"primaryKey"`
+	ProductID        uint          `gorm:"not null;index"` // cópia do produto do release
+	ReleaseID        uint          `gorm:"not null;index"`
+	Release          *Release      `gorm:"foreignKey:ReleaseID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Title            string        `gorm:"size:255;not null"`
+	Description      string        `gorm:"type:text"`
+	Severity         IssueSeverity `gorm:"type:varchar(10);not null;default:medium"`
+	Workaround       string        `gorm:"type:text"`
+	FixedInReleaseID *uint         `gorm:"index"`
+	FixedIn          *Release      `gorm:"foreignKey:FixedInReleaseID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
@@ -0,0 +1,65 @@
+// internal/models/link_kind.go
+package models
+
+import (
+	"net/url"
+	"path"
+	"strings"
+)
+
+// LinkKind diz o que um FirmwareLink entrega, para clientes (ex.: a
+// ferramenta de OTA) escolherem o arquivo sem depender da descrição.
+type LinkKind string
+
+const (
+	LinkKindFirmware        LinkKind = "firmware"
+	LinkKindBootloader      LinkKind = "bootloader"
+	LinkKindChecksum        LinkKind = "checksum"
+	LinkKindReleaseNotesPDF LinkKind = "release-notes-pdf"
+	LinkKindSBOM            LinkKind = "sbom"
+	LinkKindOther           LinkKind = "other"
+)
+
+// ParseLinkKind normaliza o kind informado pelo cliente (" Firmware " vira
+// "firmware"); não valida.
+func ParseLinkKind(s string) LinkKind {
+	return LinkKind(strings.ToLower(strings.TrimSpace(s)))
+}
+
+func (k LinkKind) Valid() bool {
+	switch k {
+	case LinkKindFirmware, LinkKindBootloader, LinkKindChecksum, LinkKindReleaseNotesPDF, LinkKindSBOM, LinkKindOther:
+		return true
+	default:
+		return false
+	}
+}
+
+// GuessLinkKind deduz o tipo pelo nome do arquivo na URL (e, para separar
+// bootloader de firmware, também pela descrição).
+func GuessLinkKind(rawURL, description string) LinkKind {
+	name := rawURL
+	if u, err := url.Parse(rawURL); err == nil {
+		name = u.Path
+	}
+	name = strings.ToLower(path.Base(name))
+	desc := strings.ToLower(description)
+
+	switch {
+	case strings.Contains(name, "sbom"), strings.HasSuffix(name, ".spdx"), strings.HasSuffix(name, ".spdx.json"),
+		strings.HasSuffix(name, ".cdx.json"), strings.HasSuffix(name, ".cdx.xml"):
+		return LinkKindSBOM
+	}
+	switch path.Ext(name) {
+	case ".sha256", ".sha256sum", ".sha512", ".md5":
+		return LinkKindChecksum
+	case ".pdf":
+		return LinkKindReleaseNotesPDF
+	case ".bin", ".hex", ".img", ".fw", ".dfu", ".srec", ".elf":
+		if strings.Contains(name, "boot") || strings.Contains(desc, "boot") {
+			return LinkKindBootloader
+		}
+		return LinkKindFirmware
+	}
+	return LinkKindOther
+}
@@ -0,0 +1,40 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+)
+
+func TestGuessLinkKind(t *testing.T) {
+	for _, tc := range []struct {
+		url, desc string
+		want      models.LinkKind
+	}{
+		{"https://cdn.example.com/fw/app-1.2.0.bin", "Firmware", models.LinkKindFirmware},
+		{"https://cdn.example.com/fw/APP.HEX?token=abc", "", models.LinkKindFirmware},
+		{"https://cdn.example.com/fw/boot-1.0.bin", "", models.LinkKindBootloader},
+		{"https://cdn.example.com/fw/loader.dfu", "Bootloader da placa", models.LinkKindBootloader},
+		{"https://cdn.example.com/fw/app.bin.sha256", "", models.LinkKindChecksum},
+		{"https://cdn.example.com/fw/app.md5", "", models.LinkKindChecksum},
+		{"https://cdn.example.com/docs/notas-1.2.0.pdf", "", models.LinkKindReleaseNotesPDF},
+		{"https://cdn.example.com/sbom/app.cdx.json", "", models.LinkKindSBOM},
+		{"https://cdn.example.com/app.spdx.json", "", models.LinkKindSBOM},
+		{"https://cdn.example.com/app-sbom.txt", "", models.LinkKindSBOM},
+		{"https://example.com/download?id=12", "Firmware", models.LinkKindOther},
+		{"", "", models.LinkKindOther},
+	} {
+		if got := models.GuessLinkKind(tc.url, tc.desc); got != tc.want {
+			t.Errorf("GuessLinkKind(%q, %q) = %q, esperava %q", tc.url, tc.desc, got, tc.want)
+		}
+	}
+}
+
+func TestParseLinkKind(t *testing.T) {
+	if got := models.ParseLinkKind("  Release-Notes-PDF "); got != models.LinkKindReleaseNotesPDF {
+		t.Fatalf("got %q", got)
+	}
+	if models.ParseLinkKind("zip").Valid() {
+		t.Fatal("zip não é um kind válido")
+	}
+}
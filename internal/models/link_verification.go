@@ -0,0 +1,32 @@
+// internal/models/link_verification.go
+package models
+
+import "time"
+
+type VerificationResult string
+
+const (
+	VerificationOK       VerificationResult = "ok"
+	VerificationMismatch VerificationResult = "mismatch" // SHA-256 diferente do gravado no upload
+	VerificationChanged  VerificationResult = "changed"  // sem checksum gravado: diferente da última verificação
+	VerificationMissing  VerificationResult = "missing"  // 404/410 no servidor de arquivos
+	VerificationError    VerificationResult = "error"    // falha de rede ou resposta inesperada
+)
+
+// LinkVerification é uma conferência do arquivo de um FirmwareLink: o
+// arquivo é baixado de novo e o SHA-256 comparado com o esperado.
+type LinkVerification struct {
+	ID             uint               `gorm:"primaryKey"`
+	LinkID         uint               `gorm:"not null;index:idx_link_verif_link_checked,priority:1"`
+	Link           *FirmwareLink      `gorm:"foreignKey:LinkID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	ReleaseID      uint               `gorm:"not null;index"`
+	URL            string             `gorm:"size:2048;not null"` // URL conferida (o link pode mudar depois)
+	Result         VerificationResult `gorm:"type:varchar(10);not null;index"`
+	ExpectedSHA256 string             `gorm:"size:64"` // vazio = primeira conferência de link sem checksum
+	ActualSHA256   string             `gorm:"size:64"`
+	ExpectedSize   int64
+	ActualSize     int64
+	HTTPStatus     int
+	Error          string    `gorm:"size:500"`
+	CheckedAt      time.Time `gorm:"not null;index:idx_link_verif_link_checked,priority:2"`
+}
@@ -0,0 +1,51 @@
+// internal/models/product.go
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+type ProductCategory struct {
+	ID        uint   `gorm:"primaryKey"`
+	Name      string `gorm:"size:60;not null"`
+	Key       string `gorm:"size:60;not null;uniqueIndex"` // NormalizeKey(Name)
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type Product struct {
+	ID         uint             `gorm:"primaryKey"`
+	CategoryID *uint            `gorm:"index"`
+	Category   *ProductCategory `gorm:"foreignKey:CategoryID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	Name       string           `gorm:"size:120;not null"`
+	Key        string           `gorm:"size:120;not null;uniqueIndex"` // NormalizeKey(Name)
+	Modules    []ProductModule  `gorm:"constraint:OnDelete:CASCADE"`
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NormalizeKey gera a identidade de um nome digitado livremente:
+// "Wallbox  AC" e "wallbox ac" viram a mesma chave.
+func NormalizeKey(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
+
+// ProductModule é um módulo de hardware registrado para o produto
+// (ex.: "PCB A7"). Quando o produto tem módulos registrados, os módulos e
+// links de cada release precisam bater com este cadastro.
+type ProductModule struct {
+	ID             uint   `gorm:"primaryKey"`
+	ProductID      uint   `gorm:"not null;uniqueIndex:idx_product_module_key"`
+	Name           string `gorm:"size:32;not null"` // valor usado em ReleaseModule.Module
+	Key            string `gorm:"size:32;not null;uniqueIndex:idx_product_module_key"`
+	DisplayName    string `gorm:"size:120"`
+	VersionPattern string `gorm:"size:255"` // regex (ancorada); vazio = qualquer versão reconhecida
+	Mandatory      bool
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// LinkModuleGeneral marca um link que não pertence a um módulo específico
+// (valor padrão dos uploads sem "linkModule").
+const LinkModuleGeneral = "default"
@@ -1,7 +1,11 @@
 // internal/models/release.go
 package models
 
-import "time"
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
 
 // 1. Tipo e constantes
 type FirmwareStatus string
@@ -21,25 +25,88 @@ func (s FirmwareStatus) Valid() bool {
 	}
 }
 
+// Máquina de estados do status: de -> para -> papéis autorizados.
+// A origem "" representa a criação do release.
+var statusTransitions = map[FirmwareStatus]map[FirmwareStatus][]Role{
+	"": {
+		FirmwareStatusRevisao:       {RoleAdmin, RoleEditor},
+		FirmwareStatusProducao:      {RoleAdmin, RoleEditor},
+		FirmwareStatusDescontinuado: {RoleAdmin},
+	},
+	FirmwareStatusRevisao: {
+		FirmwareStatusProducao:      {RoleAdmin, RoleEditor},
+		FirmwareStatusDescontinuado: {RoleAdmin},
+	},
+	FirmwareStatusProducao: {
+		FirmwareStatusRevisao:       {RoleAdmin, RoleEditor},
+		FirmwareStatusDescontinuado: {RoleAdmin},
+	},
+	FirmwareStatusDescontinuado: {
+		FirmwareStatusProducao: {RoleAdmin},
+	},
+}
+
+// CanTransition informa se a transição s -> to existe.
+func (s FirmwareStatus) CanTransition(to FirmwareStatus) bool {
+	_, ok := statusTransitions[s][to]
+	return ok
+}
+
+// TransitionAllowed informa se o papel pode executar a transição s -> to.
+func (s FirmwareStatus) TransitionAllowed(to FirmwareStatus, role Role) bool {
+	for _, r := range statusTransitions[s][to] {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// ReleaseStatusTransition registra cada mudança de status (quem, quando, por quê).
+type ReleaseStatusTransition struct {
+	ID              uint           `gorm:"primaryKey"`
+	ReleaseID       uint           `gorm:"index;not null"`
+	FromStatus      FirmwareStatus `gorm:"type:varchar(20)"` // "" na criação
+	ToStatus        FirmwareStatus `gorm:"type:varchar(20);not null"`
+	Reason          string         `gorm:"type:text;not null"`
+	ChangedByUserID uint
+	ChangedBy       *User     `gorm:"foreignKey:ChangedByUserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	CreatedAt       time.Time `gorm:"autoCreateTime"`
+}
+
 // 2. Campo novo no model Release
 type Release struct {
 	ID              uint   `gorm:"primaryKey"`
-	Version         string `gorm:"uniqueIndex;size:32"`
+	Version         string `gorm:"size:32;uniqueIndex:idx_release_product_version,priority:2"` // única por produto
 	PreviousVersion string `gorm:"size:32"`
 	OTA             bool
 	OTAObs          string `gorm:"size:255"`
 	ReleaseDate     time.Time `json:"releaseDate" gorm:"index"`
 	ImportantNote   string           `gorm:"type:text"`
+	ProductID       *uint            `json:"productId" gorm:"index;uniqueIndex:idx_release_product_version,priority:1"`
+	Product         *Product         `json:"product,omitempty" gorm:"foreignKey:ProductID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	// cópias dos nomes do catálogo, mantidas para busca e compatibilidade
 	ProductCategory string           `json:"productCategory" gorm:"size:60;index"`
 	ProductName     string           `json:"productName"     gorm:"size:120;index"`
 	Status          FirmwareStatus   `json:"status" gorm:"type:varchar(20);default:producao;index"`
+	// controle de concorrência otimista: incrementado a cada alteração, vira o ETag
+	LockVersion     int              `json:"lockVersion" gorm:"not null;default:1"`
 	CreatedByUserID uint             `json:"-"`
 	CreatedBy       *User            `json:"createdBy,omitempty" gorm:"foreignKey:CreatedByUserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
 	Modules         []ReleaseModule  `gorm:"constraint:OnDelete:CASCADE"`
 	Entries         []ChangelogEntry `gorm:"constraint:OnDelete:CASCADE"`
 	Links          []FirmwareLink    `gorm:"constraint:OnDelete:CASCADE"`
+	Tags            []Tag            `gorm:"many2many:release_tags;constraint:OnDelete:CASCADE"`
+	StatusTransitions []ReleaseStatusTransition `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	ApprovalRequests  []ReleaseApprovalRequest  `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Revisions         []ReleaseRevision         `json:"-" gorm:"constraint:OnDelete:CASCADE"`
+	Translations      []ReleaseTranslation      `json:"-" gorm:"constraint:OnDelete:CASCADE"`
 	CreatedAt       time.Time         `json:"createdAt" gorm:"autoCreateTime"`
 	UpdatedAt       time.Time         `json:"updatedAt" gorm:"autoUpdateTime"`
+	// lixeira: release apagado fica oculto até a purga definitiva
+	DeletedAt       gorm.DeletedAt    `json:"deletedAt,omitempty" gorm:"index"`
+	DeletedByUserID *uint             `json:"-"`
+	DeletedBy       *User             `json:"-" gorm:"foreignKey:DeletedByUserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL"`
 }
 
 type FirmwareLink struct {
@@ -48,6 +115,15 @@ type FirmwareLink struct {
 	Module    string    `gorm:"size:120;not null"`
 	Description string  `gorm:"size:255;not null"`
 	URL       string    `gorm:"size:2048;not null"`
+	Kind      LinkKind  `gorm:"type:varchar(20);not null;default:'';index"` // vazio só em linhas antigas (ver BackfillLinkKinds)
+	// revisão de hardware alvo (ex.: "B"); vazio = qualquer revisão
+	HardwareRevision string `gorm:"size:40;index"`
+	// metadados do arquivo enviado (vazios em links externos)
+	SHA256           string `gorm:"size:64"` // hex, minúsculo
+	SizeBytes        int64
+	MIMEType         string `gorm:"size:120"`
+	OriginalFilename string `gorm:"size:255"`
+	SignatureKeyID   string `gorm:"size:16"` // chave do fabricante que assinou o upload
 	CreatedAt time.Time
 	UpdatedAt time.Time
 }
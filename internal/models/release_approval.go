@@ -0,0 +1,46 @@
+// internal/models/release_approval.go
+package models
+
+import "time"
+
+type ApprovalStatus string
+
+const (
+	ApprovalPendente  ApprovalStatus = "pendente"
+	ApprovalAprovado  ApprovalStatus = "aprovado"
+	ApprovalRejeitado ApprovalStatus = "rejeitado"
+	ApprovalCancelado ApprovalStatus = "cancelado" // release editado ou devolvido para revisão
+)
+
+type ApprovalDecision string
+
+const (
+	DecisionAprovar  ApprovalDecision = "aprovado"
+	DecisionRejeitar ApprovalDecision = "rejeitado"
+)
+
+// ReleaseApprovalRequest é uma rodada de aprovação de um release em
+// revisão. Só o pedido mais recente vale.
+type ReleaseApprovalRequest struct {
+	ID                uint `gorm:"primaryKey"`
+	ReleaseID         uint `gorm:"index;not null"`
+	SubmittedByUserID uint
+	SubmittedBy       *User             `gorm:"foreignKey:SubmittedByUserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	Status            ApprovalStatus    `gorm:"type:varchar(20);not null;index"`
+	RequiredApprovals int               `gorm:"not null"`
+	Comment           string            `gorm:"type:text"`
+	Decisions         []ReleaseApproval `gorm:"foreignKey:RequestID;constraint:OnDelete:CASCADE"`
+	CreatedAt         time.Time         `gorm:"autoCreateTime"`
+	ClosedAt          *time.Time
+}
+
+// ReleaseApproval é o parecer de um aprovador; um por usuário e pedido.
+type ReleaseApproval struct {
+	ID        uint             `gorm:"primaryKey"`
+	RequestID uint             `gorm:"not null;uniqueIndex:idx_approval_request_user"`
+	UserID    uint             `gorm:"not null;uniqueIndex:idx_approval_request_user"`
+	User      *User            `gorm:"foreignKey:UserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	Decision  ApprovalDecision `gorm:"type:varchar(20);not null"`
+	Comment   string           `gorm:"type:text"`
+	CreatedAt time.Time        `gorm:"autoCreateTime"`
+}
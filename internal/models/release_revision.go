@@ -0,0 +1,135 @@
+// internal/models/release_revision.go
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ReleaseRevision guarda o grafo completo do release (campos, tags,
+// módulos, entradas e links) após cada alteração.
+type ReleaseRevision struct {
+	ID           uint   `gorm:"primaryKey"`
+	ReleaseID    uint   `gorm:"not null;uniqueIndex:idx_release_revision"`
+	Revision     int    `gorm:"not null;uniqueIndex:idx_release_revision"` // 1, 2, 3... por release
+	Snapshot     string `gorm:"type:text;not null"`                        // JSON de ReleaseSnapshot
+	Note         string `gorm:"size:255"`
+	AuthorUserID uint
+	Author       *User     `gorm:"foreignKey:AuthorUserID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:RESTRICT"`
+	CreatedAt    time.Time `gorm:"autoCreateTime"`
+}
+
+// ReleaseSnapshot é o formato serializado de uma revisão. Tem campos
+// próprios (e não o model Release) para não vazar dados de usuário e para
+// que revisões antigas continuem legíveis quando o model mudar.
+type ReleaseSnapshot struct {
+	Version         string           `json:"version"`
+	PreviousVersion string           `json:"previousVersion"`
+	OTA             bool             `json:"ota"`
+	OTAObs          string           `json:"otaObs"`
+	ReleaseDate     time.Time        `json:"releaseDate"`
+	ImportantNote   string           `json:"importantNote"`
+	ProductID       *uint            `json:"productId"`
+	ProductCategory string           `json:"productCategory"`
+	ProductName     string           `json:"productName"`
+	Status          FirmwareStatus   `json:"status"`
+	Tags            []string         `json:"tags"` // nil em revisões anteriores às tags no histórico
+	Modules         []SnapshotModule `json:"modules"`
+	Entries         []SnapshotEntry  `json:"entries"`
+	Links           []SnapshotLink   `json:"links"`
+}
+
+type SnapshotModule struct {
+	ID      uint   `json:"id"`
+	Module  string `json:"module"`
+	Version string `json:"version"`
+	Updated bool   `json:"updated"`
+}
+
+type SnapshotEntry struct {
+	ID             uint                `json:"id"`
+	ItemOrder      int                 `json:"itemOrder"`
+	Classification EntryClassification `json:"classification"`
+	Observation    string              `json:"observation"`
+	AdvisoryID     *uint               `json:"advisoryId,omitempty"`
+}
+
+type SnapshotLink struct {
+	ID               uint     `json:"id"`
+	Module           string   `json:"module"`
+	Description      string   `json:"description"`
+	URL              string   `json:"url"`
+	Kind             LinkKind `json:"kind,omitempty"`
+	HardwareRevision string   `json:"hardwareRevision,omitempty"`
+	SHA256           string   `json:"sha256,omitempty"`
+	SizeBytes        int64    `json:"size,omitempty"`
+	MIMEType         string   `json:"mimeType,omitempty"`
+	OriginalFilename string   `json:"filename,omitempty"`
+	SignatureKeyID   string   `json:"signatureKeyId,omitempty"`
+}
+
+func NewReleaseSnapshot(r *Release) ReleaseSnapshot {
+	s := ReleaseSnapshot{
+		Version: r.Version, PreviousVersion: r.PreviousVersion,
+		OTA: r.OTA, OTAObs: r.OTAObs, ReleaseDate: r.ReleaseDate,
+		ImportantNote: r.ImportantNote,
+		ProductID:     r.ProductID, ProductCategory: r.ProductCategory, ProductName: r.ProductName,
+		Status:  r.Status,
+		Tags:    make([]string, 0, len(r.Tags)),
+		Modules: make([]SnapshotModule, 0, len(r.Modules)),
+		Entries: make([]SnapshotEntry, 0, len(r.Entries)),
+		Links:   make([]SnapshotLink, 0, len(r.Links)),
+	}
+	for _, t := range r.Tags {
+		s.Tags = append(s.Tags, t.Name)
+	}
+	for _, m := range r.Modules {
+		s.Modules = append(s.Modules, SnapshotModule{ID: m.ID, Module: m.Module, Version: m.Version, Updated: m.Updated})
+	}
+	for _, e := range r.Entries {
+		s.Entries = append(s.Entries, SnapshotEntry{ID: e.ID, ItemOrder: e.ItemOrder, Classification: e.Classification, Observation: e.Observation, AdvisoryID: e.AdvisoryID})
+	}
+	for _, l := range r.Links {
+		s.Links = append(s.Links, SnapshotLink{ID: l.ID, Module: l.Module, Description: l.Description, URL: l.URL, Kind: l.Kind, HardwareRevision: l.HardwareRevision,
+			SHA256: l.SHA256, SizeBytes: l.SizeBytes, MIMEType: l.MIMEType, OriginalFilename: l.OriginalFilename,
+			SignatureKeyID: l.SignatureKeyID})
+	}
+	return s
+}
+
+// Release remonta o release da revisão (sem usuário e sem datas de controle).
+// Revisões sem tags gravadas devolvem Tags nil, que na restauração significa
+// manter as tags atuais.
+func (s ReleaseSnapshot) Release(releaseID uint) Release {
+	r := Release{
+		ID: releaseID, Version: s.Version, PreviousVersion: s.PreviousVersion,
+		OTA: s.OTA, OTAObs: s.OTAObs, ReleaseDate: s.ReleaseDate,
+		ImportantNote: s.ImportantNote,
+		ProductID:     s.ProductID, ProductCategory: s.ProductCategory, ProductName: s.ProductName,
+		Status: s.Status,
+	}
+	if s.Tags != nil {
+		r.Tags = make([]Tag, 0, len(s.Tags))
+		for _, n := range s.Tags {
+			r.Tags = append(r.Tags, Tag{Name: n})
+		}
+	}
+	for _, m := range s.Modules {
+		r.Modules = append(r.Modules, ReleaseModule{ID: m.ID, ReleaseID: releaseID, Module: m.Module, Version: m.Version, Updated: m.Updated})
+	}
+	for _, e := range s.Entries {
+		r.Entries = append(r.Entries, ChangelogEntry{ID: e.ID, ReleaseID: releaseID, ItemOrder: e.ItemOrder, Classification: e.Classification, Observation: e.Observation, AdvisoryID: e.AdvisoryID})
+	}
+	for _, l := range s.Links {
+		r.Links = append(r.Links, FirmwareLink{ID: l.ID, ReleaseID: releaseID, Module: l.Module, Description: l.Description, URL: l.URL, Kind: l.Kind, HardwareRevision: l.HardwareRevision,
+			SHA256: l.SHA256, SizeBytes: l.SizeBytes, MIMEType: l.MIMEType, OriginalFilename: l.OriginalFilename,
+			SignatureKeyID: l.SignatureKeyID})
+	}
+	return r
+}
+
+func (rv *ReleaseRevision) Decode() (ReleaseSnapshot, error) {
+	var s ReleaseSnapshot
+	err := json.Unmarshal([]byte(rv.Snapshot), &s)
+	return s, err
+}
@@ -0,0 +1,17 @@
+// internal/models/signing_key.go
+package models
+
+import "time"
+
+// SigningKey é uma chave pública do fabricante de um produto. Firmware
+// enviado pelo upload só é publicado com assinatura de uma delas.
+type SigningKey struct {
+	ID        uint     `gorm:"primaryKey"`
+	ProductID uint     `gorm:"not null;uniqueIndex:idx_signing_key_product_key,priority:1"`
+	Product   *Product `gorm:"foreignKey:ProductID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE"`
+	Name      string   `gorm:"size:120;not null"`
+	KeyID     string   `gorm:"size:16;not null;uniqueIndex:idx_signing_key_product_key,priority:2"` // signing.PublicKey.ID
+	Algorithm string   `gorm:"size:20;not null"`                                                    // ed25519|ecdsa-p256
+	PublicKey string   `gorm:"type:text;not null"`                                                  // PEM
+	CreatedAt time.Time
+}
@@ -0,0 +1,13 @@
+// internal/models/tag.go
+package models
+
+import "time"
+
+// Tag é um rótulo livre de release ("hotfix", "LTS", "cliente-X").
+// Releases e tags se ligam pela tabela release_tags.
+type Tag struct {
+	ID        uint   `gorm:"primaryKey"`
+	Name      string `gorm:"size:60;not null"`
+	Key       string `gorm:"size:60;not null;uniqueIndex"` // NormalizeKey(Name)
+	CreatedAt time.Time
+}
@@ -0,0 +1,30 @@
+// internal/models/translation.go
+package models
+
+import "time"
+
+// ReleaseTranslation guarda os textos do release em outro idioma. O texto
+// original (idioma de origem) continua nas colunas do próprio Release.
+// Os campos *Source guardam o hash do original traduzido: se o original
+// mudar, a tradução fica desatualizada e deixa de ser servida.
+type ReleaseTranslation struct {
+	ID                  uint   `gorm:"primaryKey"`
+	ReleaseID           uint   `gorm:"not null;uniqueIndex:idx_release_translation,priority:1"`
+	Lang                string `gorm:"size:16;not null;uniqueIndex:idx_release_translation,priority:2"` // ex.: "es", "en"
+	ImportantNote       string `gorm:"type:text"`
+	ImportantNoteSource string `gorm:"size:64"` // SHA-256 do original; vazio = legado
+	OTAObs              string `gorm:"size:255"`
+	OTAObsSource        string `gorm:"size:64"`
+	UpdatedAt           time.Time
+}
+
+// ChangelogEntryTranslation é a observação de uma entrada em outro idioma.
+type ChangelogEntryTranslation struct {
+	ID          uint   `gorm:"primaryKey"`
+	EntryID     uint   `gorm:"not null;uniqueIndex:idx_entry_translation,priority:1"`
+	Lang        string `gorm:"size:16;not null;uniqueIndex:idx_entry_translation,priority:2"`
+	Observation string `gorm:"type:text;not null"`
+	// SHA-256 da observação original traduzida; vazio = legado
+	ObservationSource string `gorm:"size:64"`
+	UpdatedAt         time.Time
+}
@@ -7,6 +7,9 @@ const (
 	RoleAdmin  Role = "admin"
 	RoleEditor Role = "editor"
 	RoleViewer Role = "viewer"
+	// RoleApprover dá parecer nos pedidos de aprovação (ex.: líder de QA,
+	// dono do produto); não edita releases.
+	RoleApprover Role = "approver"
 )
 
 type User struct {
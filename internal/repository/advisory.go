@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+)
+
+type AdvisoryFilter struct {
+	ProductID uint
+	Severity  string
+	Q         string // identificador ou título
+}
+
+type AdvisoryRepository interface {
+	List(f AdvisoryFilter) ([]models.SecurityAdvisory, error)
+	GetByID(id uint) (*models.SecurityAdvisory, error)
+	GetByIDs(ids []uint) ([]models.SecurityAdvisory, error)
+	Create(a *models.SecurityAdvisory) error
+	Update(a *models.SecurityAdvisory) error
+	Delete(id uint) error
+	// CountEntries conta entradas de changelog (inclusive na lixeira) ligadas ao boletim.
+	CountEntries(id uint) (int64, error)
+	// Releases lista os releases (fora da lixeira) com entradas ligadas ao boletim.
+	Releases(id uint) ([]models.Release, error)
+}
+
+type advisoryRepository struct{ db *gorm.DB }
+
+func NewAdvisoryRepository(db *gorm.DB) AdvisoryRepository {
+	return &advisoryRepository{db: db}
+}
+
+func (r *advisoryRepository) List(f AdvisoryFilter) ([]models.SecurityAdvisory, error) {
+	tx := r.db.Model(&models.SecurityAdvisory{}).Preload("Product")
+	if f.ProductID != 0 {
+		tx = tx.Where("product_id = ?", f.ProductID)
+	}
+	if f.Severity != "" {
+		tx = tx.Where("severity = ?", f.Severity)
+	}
+	if q := strings.TrimSpace(f.Q); q != "" {
+		like := "%" + q + "%"
+		tx = tx.Where("identifier ILIKE ? OR title ILIKE ?", like, like)
+	}
+	var out []models.SecurityAdvisory
+	if err := tx.Order("disclosed_at DESC NULLS LAST, id DESC").Find(&out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *advisoryRepository) GetByID(id uint) (*models.SecurityAdvisory, error) {
+	var a models.SecurityAdvisory
+	if err := r.db.Preload("Product").First(&a, id).Error; err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+func (r *advisoryRepository) GetByIDs(ids []uint) ([]models.SecurityAdvisory, error) {
+	var out []models.SecurityAdvisory
+	if len(ids) == 0 {
+		return out, nil
+	}
+	if err := r.db.Where("id IN ?", ids).Find(&out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *advisoryRepository) Create(a *models.SecurityAdvisory) error {
+	return r.db.Omit("Product").Create(a).Error
+}
+
+func (r *advisoryRepository) Update(a *models.SecurityAdvisory) error {
+	return r.db.Omit("Product").Save(a).Error
+}
+
+func (r *advisoryRepository) Delete(id uint) error {
+	res := r.db.Delete(&models.SecurityAdvisory{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *advisoryRepository) CountEntries(id uint) (int64, error) {
+	var n int64
+	err := r.db.Model(&models.ChangelogEntry{}).Where("advisory_id = ?", id).Count(&n).Error
+	return n, err
+}
+
+func (r *advisoryRepository) Releases(id uint) ([]models.Release, error) {
+	sub := r.db.Model(&models.ChangelogEntry{}).Select("release_id").Where("advisory_id = ?", id)
+	var out []models.Release
+	if err := r.db.Where("id IN (?)", sub).Order("release_date ASC, id ASC").Find(&out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
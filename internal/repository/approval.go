@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+)
+
+type ApprovalRepository interface {
+	Latest(releaseID uint) (*models.ReleaseApprovalRequest, error)
+	CreateRequest(r *models.ReleaseApprovalRequest) error
+	AddDecision(d *models.ReleaseApproval) error
+	CancelOpen(releaseID uint) error
+}
+
+type approvalRepository struct{ db *gorm.DB }
+
+func NewApprovalRepository(db *gorm.DB) ApprovalRepository {
+	return &approvalRepository{db: db}
+}
+
+func (r *approvalRepository) Latest(releaseID uint) (*models.ReleaseApprovalRequest, error) {
+	var out models.ReleaseApprovalRequest
+	err := r.db.
+		Preload("SubmittedBy").
+		Preload("Decisions", func(tx *gorm.DB) *gorm.DB { return tx.Order("created_at ASC, id ASC") }).
+		Preload("Decisions.User").
+		Where("release_id = ?", releaseID).
+		Order("id DESC").
+		First(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return &out, nil
+}
+
+func (r *approvalRepository) CreateRequest(req *models.ReleaseApprovalRequest) error {
+	return r.db.Create(req).Error
+}
+
+// ErrRequestClosed: o pedido já não estava pendente ao gravar o parecer.
+var ErrRequestClosed = errors.New("pedido de aprovação já encerrado")
+
+// AddDecision grava o parecer com o pedido travado (SELECT ... FOR UPDATE)
+// e, na mesma transação, encerra o pedido: rejeitado na primeira
+// rejeição, aprovado quando as aprovações chegam ao exigido. Pareceres
+// concorrentes são serializados; o que chega depois do encerramento falha
+// com ErrRequestClosed.
+func (r *approvalRepository) AddDecision(d *models.ReleaseApproval) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var req models.ReleaseApprovalRequest
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&req, d.RequestID).Error; err != nil {
+			return err
+		}
+		if req.Status != models.ApprovalPendente {
+			return ErrRequestClosed
+		}
+		if err := tx.Create(d).Error; err != nil {
+			return err
+		}
+
+		var closeAs models.ApprovalStatus
+		if d.Decision == models.DecisionRejeitar {
+			closeAs = models.ApprovalRejeitado
+		} else {
+			var approved int64
+			if err := tx.Model(&models.ReleaseApproval{}).
+				Where("request_id = ? AND decision = ?", req.ID, models.DecisionAprovar).
+				Count(&approved).Error; err != nil {
+				return err
+			}
+			if approved >= int64(req.RequiredApprovals) {
+				closeAs = models.ApprovalAprovado
+			}
+		}
+		if closeAs == "" {
+			return nil
+		}
+		return tx.Model(&models.ReleaseApprovalRequest{}).
+			Where("id = ?", req.ID).
+			Updates(map[string]any{"status": closeAs, "closed_at": time.Now()}).Error
+	})
+}
+
+// CancelOpen invalida pedidos pendentes ou aprovados (ex.: o conteúdo mudou).
+func (r *approvalRepository) CancelOpen(releaseID uint) error {
+	return r.db.Model(&models.ReleaseApprovalRequest{}).
+		Where("release_id = ? AND status IN ?", releaseID, []models.ApprovalStatus{models.ApprovalPendente, models.ApprovalAprovado}).
+		Updates(map[string]any{"status": models.ApprovalCancelado, "closed_at": time.Now()}).Error
+}
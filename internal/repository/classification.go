@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+)
+
+type ClassificationRepository interface {
+	List() ([]models.Classification, error)
+	GetByID(id uint) (*models.Classification, error)
+	Create(c *models.Classification) error
+	Update(c *models.Classification) error
+	Delete(id uint) error
+	// CountEntries conta entradas de changelog (inclusive na lixeira) que usam a chave.
+	CountEntries(key string) (int64, error)
+}
+
+type classificationRepository struct{ db *gorm.DB }
+
+func NewClassificationRepository(db *gorm.DB) ClassificationRepository {
+	return &classificationRepository{db: db}
+}
+
+func (r *classificationRepository) List() ([]models.Classification, error) {
+	var out []models.Classification
+	if err := r.db.Order("sort_order ASC, id ASC").Find(&out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *classificationRepository) GetByID(id uint) (*models.Classification, error) {
+	var c models.Classification
+	if err := r.db.First(&c, id).Error; err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *classificationRepository) Create(c *models.Classification) error {
+	return r.db.Create(c).Error
+}
+
+func (r *classificationRepository) Update(c *models.Classification) error {
+	return r.db.Save(c).Error
+}
+
+func (r *classificationRepository) Delete(id uint) error {
+	res := r.db.Delete(&models.Classification{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *classificationRepository) CountEntries(key string) (int64, error) {
+	var n int64
+	err := r.db.Model(&models.ChangelogEntry{}).Where("classification = ?", key).Count(&n).Error
+	return n, err
+}
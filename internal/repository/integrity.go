@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+)
+
+type IntegrityRepository interface {
+	// Links traz os links de releases fora da lixeira cuja URL começa com
+	// urlPrefix (arquivos do nosso servidor).
+	Links(urlPrefix string) ([]models.FirmwareLink, error)
+	GetLink(releaseID, linkID uint) (*models.FirmwareLink, error)
+	Create(v *models.LinkVerification) error
+	// History devolve as conferências do link, da mais recente para a mais
+	// antiga (limit <= 0 = todas).
+	History(linkID uint, limit int) ([]models.LinkVerification, error)
+	// Latest devolve a conferência mais recente de cada link de release
+	// fora da lixeira.
+	Latest() ([]models.LinkVerification, error)
+}
+
+type integrityRepository struct{ db *gorm.DB }
+
+func NewIntegrityRepository(db *gorm.DB) IntegrityRepository {
+	return &integrityRepository{db: db}
+}
+
+func (r *integrityRepository) Links(urlPrefix string) ([]models.FirmwareLink, error) {
+	var out []models.FirmwareLink
+	err := r.db.Model(&models.FirmwareLink{}).
+		Joins("JOIN releases ON releases.id = firmware_links.release_id AND releases.deleted_at IS NULL").
+		Where("firmware_links.url LIKE ?", likeEscaper.Replace(urlPrefix)+"%").
+		Order("firmware_links.id ASC").
+		Find(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// likeEscaper protege os curingas do LIKE (barra invertida é o escape padrão do Postgres).
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (r *integrityRepository) GetLink(releaseID, linkID uint) (*models.FirmwareLink, error) {
+	var l models.FirmwareLink
+	if err := r.db.Where("release_id = ?", releaseID).First(&l, linkID).Error; err != nil {
+		return nil, err
+	}
+	return &l, nil
+}
+
+func (r *integrityRepository) Create(v *models.LinkVerification) error {
+	return r.db.Omit("Link").Create(v).Error
+}
+
+func (r *integrityRepository) History(linkID uint, limit int) ([]models.LinkVerification, error) {
+	q := r.db.Where("link_id = ?", linkID).Order("checked_at DESC, id DESC")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	var out []models.LinkVerification
+	if err := q.Find(&out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *integrityRepository) Latest() ([]models.LinkVerification, error) {
+	var out []models.LinkVerification
+	err := r.db.Raw(`
+		SELECT DISTINCT ON (v.link_id) v.*
+		FROM link_verifications v
+		JOIN firmware_links l ON l.id = v.link_id
+		JOIN releases ON releases.id = l.release_id AND releases.deleted_at IS NULL
+		ORDER BY v.link_id, v.checked_at DESC, v.id DESC`).
+		Scan(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
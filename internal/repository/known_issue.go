@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+)
+
+type KnownIssueRepository interface {
+	// ListByProducts traz os problemas com o release de origem e o da
+	// correção (releases na lixeira vêm como nil).
+	ListByProducts(productIDs []uint) ([]models.KnownIssue, error)
+	GetByID(id uint) (*models.KnownIssue, error)
+	Create(k *models.KnownIssue) error
+	Update(k *models.KnownIssue) error
+	Delete(id uint) error
+}
+
+type knownIssueRepository struct{ db *gorm.DB }
+
+func NewKnownIssueRepository(db *gorm.DB) KnownIssueRepository {
+	return &knownIssueRepository{db: db}
+}
+
+func (r *knownIssueRepository) ListByProducts(productIDs []uint) ([]models.KnownIssue, error) {
+	var out []models.KnownIssue
+	if len(productIDs) == 0 {
+		return out, nil
+	}
+	err := r.db.Preload("Release").Preload("FixedIn").
+		Where("product_id IN ?", productIDs).
+		Order("id ASC").
+		Find(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *knownIssueRepository) GetByID(id uint) (*models.KnownIssue, error) {
+	var k models.KnownIssue
+	if err := r.db.Preload("Release").Preload("FixedIn").First(&k, id).Error; err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+func (r *knownIssueRepository) Create(k *models.KnownIssue) error {
+	return r.db.Omit("Release", "FixedIn").Create(k).Error
+}
+
+func (r *knownIssueRepository) Update(k *models.KnownIssue) error {
+	return r.db.Omit("Release", "FixedIn").Save(k).Error
+}
+
+func (r *knownIssueRepository) Delete(id uint) error {
+	res := r.db.Delete(&models.KnownIssue{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+)
+
+type ProductRepository interface {
+	ListCategories() ([]models.ProductCategory, error)
+	GetCategory(id uint) (*models.ProductCategory, error)
+	FindCategoryByKey(key string) (*models.ProductCategory, error)
+	CreateCategory(c *models.ProductCategory) error
+	UpdateCategory(c *models.ProductCategory) error
+	DeleteCategory(id uint) error
+
+	List(categoryID uint) ([]models.Product, error)
+	GetByID(id uint) (*models.Product, error)
+	FindByKey(key string) (*models.Product, error)
+	Create(p *models.Product) error
+	Update(p *models.Product) error
+	Delete(id uint) error
+
+	ListModules(productID uint) ([]models.ProductModule, error)
+	GetModule(productID, id uint) (*models.ProductModule, error)
+	CreateModule(m *models.ProductModule) error
+	UpdateModule(m *models.ProductModule) error
+	DeleteModule(productID, id uint) error
+}
+
+type productRepository struct{ db *gorm.DB }
+
+func NewProductRepository(db *gorm.DB) ProductRepository {
+	return &productRepository{db: db}
+}
+
+func (r *productRepository) ListCategories() ([]models.ProductCategory, error) {
+	var out []models.ProductCategory
+	if err := r.db.Order("name ASC").Find(&out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *productRepository) GetCategory(id uint) (*models.ProductCategory, error) {
+	var c models.ProductCategory
+	if err := r.db.First(&c, id).Error; err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *productRepository) FindCategoryByKey(key string) (*models.ProductCategory, error) {
+	var c models.ProductCategory
+	if err := r.db.Where("key = ?", key).First(&c).Error; err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (r *productRepository) CreateCategory(c *models.ProductCategory) error {
+	return r.db.Create(c).Error
+}
+
+// renomear a categoria também atualiza a cópia do nome nos releases
+func (r *productRepository) UpdateCategory(c *models.ProductCategory) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(c).Error; err != nil {
+			return err
+		}
+		return tx.Model(&models.Release{}).
+			Where("product_id IN (?)", tx.Model(&models.Product{}).Select("id").Where("category_id = ?", c.ID)).
+			Update("product_category", c.Name).Error
+	})
+}
+
+func (r *productRepository) DeleteCategory(id uint) error {
+	return r.db.Delete(&models.ProductCategory{}, id).Error
+}
+
+func (r *productRepository) List(categoryID uint) ([]models.Product, error) {
+	tx := r.db.Preload("Category").Order("name ASC")
+	if categoryID != 0 {
+		tx = tx.Where("category_id = ?", categoryID)
+	}
+	var out []models.Product
+	if err := tx.Find(&out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *productRepository) GetByID(id uint) (*models.Product, error) {
+	var p models.Product
+	if err := r.db.Preload("Category").First(&p, id).Error; err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *productRepository) FindByKey(key string) (*models.Product, error) {
+	var p models.Product
+	if err := r.db.Preload("Category").Where("key = ?", key).First(&p).Error; err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *productRepository) Create(p *models.Product) error {
+	return r.db.Create(p).Error
+}
+
+// mantém product_name/product_category dos releases em sincronia com o catálogo
+func (r *productRepository) Update(p *models.Product) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Category").Save(p).Error; err != nil {
+			return err
+		}
+		cat := ""
+		if p.CategoryID != nil {
+			var c models.ProductCategory
+			if err := tx.First(&c, *p.CategoryID).Error; err != nil {
+				return err
+			}
+			cat = c.Name
+		}
+		return tx.Model(&models.Release{}).
+			Where("product_id = ?", p.ID).
+			Updates(map[string]any{"product_name": p.Name, "product_category": cat}).Error
+	})
+}
+
+func (r *productRepository) Delete(id uint) error {
+	return r.db.Delete(&models.Product{}, id).Error
+}
+
+func (r *productRepository) ListModules(productID uint) ([]models.ProductModule, error) {
+	var out []models.ProductModule
+	if err := r.db.Where("product_id = ?", productID).Order("name ASC").Find(&out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *productRepository) GetModule(productID, id uint) (*models.ProductModule, error) {
+	var m models.ProductModule
+	if err := r.db.Where("product_id = ?", productID).First(&m, id).Error; err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+func (r *productRepository) CreateModule(m *models.ProductModule) error {
+	return r.db.Create(m).Error
+}
+
+func (r *productRepository) UpdateModule(m *models.ProductModule) error {
+	return r.db.Save(m).Error
+}
+
+func (r *productRepository) DeleteModule(productID, id uint) error {
+	res := r.db.Where("product_id = ?", productID).Delete(&models.ProductModule{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
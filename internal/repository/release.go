@@ -1,27 +1,48 @@
 package repository
 
 import (
+	"errors"
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
 )
 
 type ReleaseFilter struct {
-	Q        string
-	Version  string
-	DateFrom *time.Time
-	DateTo   *time.Time
+	Q         string
+	Version   string
+	ProductID uint
+	DateFrom  *time.Time
+	DateTo    *time.Time
+	Tags      []string // Keys; o release precisa ter todas
 }
 
 type ReleaseRepository interface {
+	// Create grava o release e suas tags (r.Tags) na mesma transação.
 	Create(r *models.Release) error
 	GetByID(id uint) (*models.Release, error)
+	FindByProductVersion(productID uint, version string) (*models.Release, error)
 	List(f ReleaseFilter) ([]models.Release, error)
-	ReplaceRelations(id uint, modules []models.ReleaseModule, entries []models.ChangelogEntry, links []models.FirmwareLink) (*models.Release, error)
-	UpdateBaseFields(r *models.Release) error
-	Delete(id uint) error
+	UpdateRelations(id uint, lockVersion int, modules []models.ReleaseModule, entries []models.ChangelogEntry, links []models.FirmwareLink) (*models.Release, error)
+	UpdateFull(r *models.Release, modules []models.ReleaseModule, entries []models.ChangelogEntry, links []models.FirmwareLink) (*models.Release, error)
+	UpdateStatus(t *models.ReleaseStatusTransition) error
+	ListStatusTransitions(releaseID uint) ([]models.ReleaseStatusTransition, error)
+	AddRevision(rv *models.ReleaseRevision) error
+	ListRevisions(releaseID uint) ([]models.ReleaseRevision, error)
+	GetRevision(releaseID uint, revision int) (*models.ReleaseRevision, error)
+	CountRevisions(releaseID uint) (int64, error)
+	Delete(id, userID uint) error
+
+	// lixeira
+	ListTrashed() ([]models.Release, error)
+	Restore(id uint) error
+	ListTrashedBefore(t time.Time) ([]models.Release, error)
+	Purge(id uint) error
+	// URLInUseElsewhere informa se algum link de outro release (fora ou
+	// dentro da lixeira) aponta para a URL.
+	URLInUseElsewhere(url string, releaseID uint) (bool, error)
 }
 
 type releaseRepository struct {
@@ -33,7 +54,20 @@ func NewReleaseRepository(db *gorm.DB) ReleaseRepository {
 }
 
 func (r *releaseRepository) Create(rel *models.Release) error {
-	return r.db.Create(rel).Error
+	// tags entram pela Key (replaceTags), não pela associação do gorm
+	tags := rel.Tags
+	rel.Tags = nil
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(rel).Error; err != nil {
+			return err
+		}
+		if len(tags) == 0 {
+			return nil
+		}
+		return replaceTags(tx, rel.ID, tags)
+	})
+	rel.Tags = tags
+	return err
 }
 
 func (r *releaseRepository) GetByID(id uint) (*models.Release, error) {
@@ -41,6 +75,8 @@ func (r *releaseRepository) GetByID(id uint) (*models.Release, error) {
 	err := r.db.
 		Preload("Modules").
 		Preload("Entries", func(tx *gorm.DB) *gorm.DB { return tx.Order("item_order ASC") }).
+		Preload("Entries.Advisory").
+		Preload("Tags", func(tx *gorm.DB) *gorm.DB { return tx.Order("name ASC") }).
 		 Preload("Links", func(tx *gorm.DB) *gorm.DB { return tx.Order("module ASC, id ASC") }).
 		First(&out, id).Error
 	if err != nil {
@@ -49,16 +85,34 @@ func (r *releaseRepository) GetByID(id uint) (*models.Release, error) {
 	return &out, nil
 }
 
+func (r *releaseRepository) FindByProductVersion(productID uint, version string) (*models.Release, error) {
+	var ref models.Release
+	if err := r.db.Select("id").Where("product_id = ? AND version = ?", productID, version).First(&ref).Error; err != nil {
+		return nil, err
+	}
+	return r.GetByID(ref.ID)
+}
+
 func (r *releaseRepository) List(f ReleaseFilter) ([]models.Release, error) {
 	tx := r.db.Model(&models.Release{}).
 		Preload("Modules").
 		Preload("Entries", func(tx *gorm.DB) *gorm.DB { return tx.Order("item_order ASC") }).
+		Preload("Entries.Advisory").
+		Preload("Tags", func(tx *gorm.DB) *gorm.DB { return tx.Order("name ASC") }).
 		Preload("Links", func(tx *gorm.DB) *gorm.DB { return tx.Order("module ASC, id ASC") }).
 		Order("release_date DESC")
 
 	if f.Version != "" {
 		tx = tx.Where("version = ?", f.Version)
 	}
+	if f.ProductID != 0 {
+		tx = tx.Where("product_id = ?", f.ProductID)
+	}
+	for _, key := range f.Tags {
+		tx = tx.Where(`EXISTS (
+			SELECT 1 FROM release_tags rt JOIN tags t ON t.id = rt.tag_id
+			WHERE rt.release_id = releases.id AND t.key = ?)`, key)
+	}
 	if f.Q != "" {
     like := "%" + f.Q + "%"
     tx = tx.Where(
@@ -88,25 +142,144 @@ func (r *releaseRepository) List(f ReleaseFilter) ([]models.Release, error) {
 	return list, nil
 }
 
-func (r *releaseRepository) UpdateBaseFields(rel *models.Release) error {
-	return r.db.Save(rel).Error
+// ErrLockVersionChanged: o release foi alterado depois de carregado.
+var ErrLockVersionChanged = errors.New("release alterado por outra edição")
+
+// UpdateFull grava os campos do release e alinha módulos, entradas e links
+// (como UpdateRelations) numa única transação, se lock_version ainda for
+// rel.LockVersion; o incrementa ou devolve ErrLockVersionChanged sem gravar
+// nada. rel.Tags != nil troca as tags na mesma transação; nil mantém as
+// atuais.
+func (r *releaseRepository) UpdateFull(rel *models.Release, modules []models.ReleaseModule, entries []models.ChangelogEntry, links []models.FirmwareLink) (*models.Release, error) {
+	tags := rel.Tags
+	rel.Tags = nil
+	defer func() { rel.Tags = tags }()
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.Release{}).
+			Where("id = ? AND lock_version = ?", rel.ID, rel.LockVersion).
+			UpdateColumn("lock_version", gorm.Expr("lock_version + 1"))
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrLockVersionChanged
+		}
+		rel.LockVersion++
+		if err := tx.Save(rel).Error; err != nil {
+			return err
+		}
+		if tags != nil {
+			if err := replaceTags(tx, rel.ID, tags); err != nil {
+				return err
+			}
+		}
+		return syncRelations(tx, rel.ID, modules, entries, links)
+	}); err != nil {
+		return nil, err
+	}
+	return r.GetByID(rel.ID)
+}
+
+// ErrStatusChanged: o status mudou entre a leitura e a gravação.
+var ErrStatusChanged = errors.New("status do release mudou durante a operação")
+
+// UpdateStatus aplica t.FromStatus -> t.ToStatus e grava o histórico na
+// mesma transação. Falha com ErrStatusChanged se o status atual não for
+// mais t.FromStatus.
+func (r *releaseRepository) UpdateStatus(t *models.ReleaseStatusTransition) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.Release{}).
+			Where("id = ? AND status = ?", t.ReleaseID, t.FromStatus).
+			Updates(map[string]any{"status": t.ToStatus, "lock_version": gorm.Expr("lock_version + 1")})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrStatusChanged
+		}
+		return tx.Create(t).Error
+	})
 }
 
-// Estratégia “replace-all” para Modules e Entries
-func (r *releaseRepository) ReplaceRelations(id uint, modules []models.ReleaseModule, entries []models.ChangelogEntry, links []models.FirmwareLink,) (*models.Release, error) {
-	tx := r.db.Begin()
+func (r *releaseRepository) ListStatusTransitions(releaseID uint) ([]models.ReleaseStatusTransition, error) {
+	var out []models.ReleaseStatusTransition
+	if err := r.db.Preload("ChangedBy").
+		Where("release_id = ?", releaseID).
+		Order("created_at ASC, id ASC").
+		Find(&out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
 
-	if err := tx.Where("release_id = ?", id).Delete(&models.ReleaseModule{}).Error; err != nil {
-		tx.Rollback()
+// AddRevision numera a revisão como a próxima do release
+// (o índice único (release_id, revision) protege contra corrida).
+func (r *releaseRepository) AddRevision(rv *models.ReleaseRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var last int
+		if err := tx.Model(&models.ReleaseRevision{}).
+			Where("release_id = ?", rv.ReleaseID).
+			Select("COALESCE(MAX(revision), 0)").
+			Scan(&last).Error; err != nil {
+			return err
+		}
+		rv.Revision = last + 1
+		return tx.Create(rv).Error
+	})
+}
+
+// ListRevisions não carrega o snapshot (só metadados).
+func (r *releaseRepository) ListRevisions(releaseID uint) ([]models.ReleaseRevision, error) {
+	var out []models.ReleaseRevision
+	if err := r.db.Omit("snapshot").Preload("Author").
+		Where("release_id = ?", releaseID).
+		Order("revision DESC").
+		Find(&out).Error; err != nil {
 		return nil, err
 	}
-	if err := tx.Where("release_id = ?", id).Delete(&models.ChangelogEntry{}).Error; err != nil {
-		tx.Rollback()
+	return out, nil
+}
+
+func (r *releaseRepository) GetRevision(releaseID uint, revision int) (*models.ReleaseRevision, error) {
+	var out models.ReleaseRevision
+	if err := r.db.Preload("Author").
+		Where("release_id = ? AND revision = ?", releaseID, revision).
+		First(&out).Error; err != nil {
 		return nil, err
 	}
+	return &out, nil
+}
 
-	if err := tx.Where("release_id = ?", id).Delete(&models.FirmwareLink{}).Error; err != nil { tx.Rollback(); return nil, err } 
+func (r *releaseRepository) CountRevisions(releaseID uint) (int64, error) {
+	var n int64
+	err := r.db.Model(&models.ReleaseRevision{}).Where("release_id = ?", releaseID).Count(&n).Error
+	return n, err
+}
 
+// UpdateRelations alinha módulos, entradas e links com as listas dadas,
+// condicionado ao lock_version, que é incrementado na mesma transação
+// (ErrLockVersionChanged se mudou). Linhas com ID do próprio release são
+// atualizadas no lugar (o ID não muda), as sem ID viram linhas novas e as
+// ausentes são apagadas.
+func (r *releaseRepository) UpdateRelations(id uint, lockVersion int, modules []models.ReleaseModule, entries []models.ChangelogEntry, links []models.FirmwareLink) (*models.Release, error) {
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		res := tx.Model(&models.Release{}).
+			Where("id = ? AND lock_version = ?", id, lockVersion).
+			Updates(map[string]any{"lock_version": gorm.Expr("lock_version + 1"), "updated_at": time.Now()})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return ErrLockVersionChanged
+		}
+		return syncRelations(tx, id, modules, entries, links)
+	}); err != nil {
+		return nil, err
+	}
+	return r.GetByID(id)
+}
+
+func syncRelations(tx *gorm.DB, id uint, modules []models.ReleaseModule, entries []models.ChangelogEntry, links []models.FirmwareLink) error {
 	for i := range modules {
 		modules[i].ReleaseID = id
 	}
@@ -116,29 +289,122 @@ func (r *releaseRepository) ReplaceRelations(id uint, modules []models.ReleaseMo
 	for i := range links {
 		links[i].ReleaseID = id
 	}
+	if err := syncChildren(tx, id, modules, func(m *models.ReleaseModule) *uint { return &m.ID }); err != nil {
+		return err
+	}
+	if err := syncChildren(tx, id, entries, func(e *models.ChangelogEntry) *uint { return &e.ID }); err != nil {
+		return err
+	}
+	return syncChildren(tx, id, links, func(l *models.FirmwareLink) *uint { return &l.ID })
+}
 
-	if len(modules) > 0 {
-		if err := tx.Create(&modules).Error; err != nil {
-			tx.Rollback()
-			return nil, err
+// syncChildren grava rows como as linhas filhas de releaseID. IDs que não
+// pertencem ao release são descartados (a linha é criada com ID novo).
+func syncChildren[T any](tx *gorm.DB, releaseID uint, rows []T, idOf func(*T) *uint) error {
+	var existing []uint
+	if err := tx.Model(new(T)).Where("release_id = ?", releaseID).Pluck("id", &existing).Error; err != nil {
+		return err
+	}
+	owned := make(map[uint]bool, len(existing))
+	for _, id := range existing {
+		owned[id] = true
+	}
+
+	keep := make([]uint, 0, len(rows))
+	for i := range rows {
+		if id := idOf(&rows[i]); owned[*id] {
+			keep = append(keep, *id)
+		} else {
+			*id = 0
 		}
 	}
-	if len(entries) > 0 {
-		if err := tx.Create(&entries).Error; err != nil {
-			tx.Rollback()
-			return nil, err
+	del := tx.Where("release_id = ?", releaseID)
+	if len(keep) > 0 {
+		del = del.Where("id NOT IN ?", keep)
+	}
+	if err := del.Delete(new(T)).Error; err != nil {
+		return err
+	}
+
+	for i := range rows {
+		var err error
+		// associações (ex.: traduções) têm rotas próprias e não são gravadas aqui
+		if *idOf(&rows[i]) != 0 {
+			err = tx.Omit("created_at", clause.Associations).Save(&rows[i]).Error
+		} else {
+			err = tx.Omit(clause.Associations).Create(&rows[i]).Error
+		}
+		if err != nil {
+			return err
 		}
 	}
+	return nil
+}
 
-	if len(links) > 0   { if err := tx.Create(&links).Error;   err != nil { tx.Rollback(); return nil, err } }
+// Delete move o release para a lixeira (soft delete); relações e arquivos
+// ficam intactos até Purge.
+func (r *releaseRepository) Delete(id, userID uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Release{}).Where("id = ?", id).
+			UpdateColumn("deleted_by_user_id", userID).Error; err != nil {
+			return err
+		}
+		res := tx.Delete(&models.Release{}, id)
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
 
+func (r *releaseRepository) trashed() *gorm.DB {
+	return r.db.Unscoped().Model(&models.Release{}).
+		Preload("Links", func(tx *gorm.DB) *gorm.DB { return tx.Order("module ASC, id ASC") }).
+		Preload("DeletedBy").
+		Where("deleted_at IS NOT NULL")
+}
 
-	if err := tx.Commit().Error; err != nil {
+func (r *releaseRepository) ListTrashed() ([]models.Release, error) {
+	var out []models.Release
+	if err := r.trashed().Order("deleted_at DESC").Find(&out).Error; err != nil {
 		return nil, err
 	}
-	return r.GetByID(id)
+	return out, nil
+}
+
+func (r *releaseRepository) ListTrashedBefore(t time.Time) ([]models.Release, error) {
+	var out []models.Release
+	if err := r.trashed().Where("deleted_at < ?", t).Order("deleted_at ASC").Find(&out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *releaseRepository) Restore(id uint) error {
+	res := r.db.Unscoped().Model(&models.Release{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		UpdateColumns(map[string]any{"deleted_at": nil, "deleted_by_user_id": nil})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// Purge apaga de vez um release da lixeira; as relações saem por CASCADE.
+func (r *releaseRepository) Purge(id uint) error {
+	return r.db.Unscoped().Where("deleted_at IS NOT NULL").Delete(&models.Release{}, id).Error
 }
 
-func (r *releaseRepository) Delete(id uint) error {
-	return r.db.Delete(&models.Release{}, id).Error
+func (r *releaseRepository) URLInUseElsewhere(url string, releaseID uint) (bool, error) {
+	var n int64
+	err := r.db.Model(&models.FirmwareLink{}).
+		Where("url = ? AND release_id <> ?", url, releaseID).
+		Count(&n).Error
+	return n > 0, err
 }
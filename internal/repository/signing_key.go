@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+)
+
+type SigningKeyRepository interface {
+	ListByProduct(productID uint) ([]models.SigningKey, error)
+	GetByID(id uint) (*models.SigningKey, error)
+	Create(k *models.SigningKey) error
+	Delete(id uint) error
+}
+
+type signingKeyRepository struct{ db *gorm.DB }
+
+func NewSigningKeyRepository(db *gorm.DB) SigningKeyRepository {
+	return &signingKeyRepository{db: db}
+}
+
+func (r *signingKeyRepository) ListByProduct(productID uint) ([]models.SigningKey, error) {
+	var out []models.SigningKey
+	if err := r.db.Where("product_id = ?", productID).Order("id ASC").Find(&out).Error; err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *signingKeyRepository) GetByID(id uint) (*models.SigningKey, error) {
+	var k models.SigningKey
+	if err := r.db.First(&k, id).Error; err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
+
+func (r *signingKeyRepository) Create(k *models.SigningKey) error {
+	return r.db.Omit("Product").Create(k).Error
+}
+
+func (r *signingKeyRepository) Delete(id uint) error {
+	res := r.db.Delete(&models.SigningKey{}, id)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+)
+
+// TagCount é uma tag com o número de releases (fora da lixeira) que a usam.
+type TagCount struct {
+	ID    uint
+	Name  string
+	Key   string
+	Count int64
+}
+
+type TagRepository interface {
+	// List conta só releases do produto quando productID != 0.
+	List(productID uint) ([]TagCount, error)
+	// Replace troca as tags do release; tags novas são criadas pela Key.
+	// Tags sem release ficam na tabela (List não as mostra) e voltam a ser
+	// usadas pela Key.
+	Replace(releaseID uint, tags []models.Tag) error
+}
+
+type tagRepository struct{ db *gorm.DB }
+
+func NewTagRepository(db *gorm.DB) TagRepository {
+	return &tagRepository{db: db}
+}
+
+func (r *tagRepository) List(productID uint) ([]TagCount, error) {
+	join := "JOIN releases ON releases.id = release_tags.release_id AND releases.deleted_at IS NULL"
+	args := []any{}
+	if productID != 0 {
+		join += " AND releases.product_id = ?"
+		args = append(args, productID)
+	}
+	var out []TagCount
+	err := r.db.Model(&models.Tag{}).
+		Select("tags.id, tags.name, tags.key, COUNT(releases.id) AS count").
+		Joins("JOIN release_tags ON release_tags.tag_id = tags.id").
+		Joins(join, args...).
+		Group("tags.id, tags.name, tags.key").
+		Order("count DESC, tags.name ASC").
+		Scan(&out).Error
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (r *tagRepository) Replace(releaseID uint, tags []models.Tag) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return replaceTags(tx, releaseID, tags)
+	})
+}
+
+// replaceTags roda dentro da transação de quem chama (também usada ao
+// gravar o release). Tags órfãs não são apagadas aqui: um DELETE separado
+// correria contra um Replace concorrente que acabou de reaproveitá-las.
+func replaceTags(tx *gorm.DB, releaseID uint, tags []models.Tag) error {
+	for i := range tags {
+		if err := tx.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "key"}}, DoNothing: true}).
+			Create(&tags[i]).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("key = ?", tags[i].Key).First(&tags[i]).Error; err != nil {
+			return err
+		}
+	}
+	return tx.Model(&models.Release{ID: releaseID}).Association("Tags").Replace(tags)
+}
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+)
+
+type TranslationRepository interface {
+	// ForReleases traz as traduções de lang dos releases dados.
+	ForReleases(releaseIDs []uint, lang string) ([]models.ReleaseTranslation, []models.ChangelogEntryTranslation, error)
+	// ListByRelease traz as traduções do release em todos os idiomas.
+	ListByRelease(releaseID uint) ([]models.ReleaseTranslation, []models.ChangelogEntryTranslation, error)
+	// Replace troca todas as traduções do release no idioma rt.Lang.
+	Replace(rt *models.ReleaseTranslation, entries []models.ChangelogEntryTranslation) error
+	Delete(releaseID uint, lang string) error
+}
+
+type translationRepository struct{ db *gorm.DB }
+
+func NewTranslationRepository(db *gorm.DB) TranslationRepository {
+	return &translationRepository{db: db}
+}
+
+// subconsulta com os IDs das entradas dos releases
+func releaseEntryIDs(db *gorm.DB, releaseIDs ...uint) *gorm.DB {
+	return db.Model(&models.ChangelogEntry{}).Select("id").Where("release_id IN ?", releaseIDs)
+}
+
+func (r *translationRepository) ForReleases(releaseIDs []uint, lang string) ([]models.ReleaseTranslation, []models.ChangelogEntryTranslation, error) {
+	if len(releaseIDs) == 0 {
+		return nil, nil, nil
+	}
+	var rts []models.ReleaseTranslation
+	if err := r.db.Where("release_id IN ? AND lang = ?", releaseIDs, lang).Find(&rts).Error; err != nil {
+		return nil, nil, err
+	}
+	var ets []models.ChangelogEntryTranslation
+	if err := r.db.Where("lang = ? AND entry_id IN (?)", lang, releaseEntryIDs(r.db, releaseIDs...)).Find(&ets).Error; err != nil {
+		return nil, nil, err
+	}
+	return rts, ets, nil
+}
+
+func (r *translationRepository) ListByRelease(releaseID uint) ([]models.ReleaseTranslation, []models.ChangelogEntryTranslation, error) {
+	var rts []models.ReleaseTranslation
+	if err := r.db.Where("release_id = ?", releaseID).Order("lang ASC").Find(&rts).Error; err != nil {
+		return nil, nil, err
+	}
+	var ets []models.ChangelogEntryTranslation
+	if err := r.db.Where("entry_id IN (?)", releaseEntryIDs(r.db, releaseID)).Order("lang ASC, entry_id ASC").Find(&ets).Error; err != nil {
+		return nil, nil, err
+	}
+	return rts, ets, nil
+}
+
+func (r *translationRepository) Replace(rt *models.ReleaseTranslation, entries []models.ChangelogEntryTranslation) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "release_id"}, {Name: "lang"}},
+			DoUpdates: clause.AssignmentColumns([]string{"important_note", "important_note_source", "ota_obs", "ota_obs_source", "updated_at"}),
+		}).Create(rt).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("lang = ? AND entry_id IN (?)", rt.Lang, releaseEntryIDs(tx, rt.ReleaseID)).Delete(&models.ChangelogEntryTranslation{}).Error; err != nil {
+			return err
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+		return tx.Create(&entries).Error
+	})
+}
+
+func (r *translationRepository) Delete(releaseID uint, lang string) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("lang = ? AND entry_id IN (?)", lang, releaseEntryIDs(tx, releaseID)).Delete(&models.ChangelogEntryTranslation{}).Error; err != nil {
+			return err
+		}
+		res := tx.Where("release_id = ? AND lang = ?", releaseID, lang).Delete(&models.ReleaseTranslation{})
+		if res.Error != nil {
+			return res.Error
+		}
+		if res.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	})
+}
@@ -0,0 +1,286 @@
+// internal/service/advisory.go
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/cvss"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/repository"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/version"
+)
+
+var (
+	ErrAdvisoryExists = errors.New("boletim de segurança já cadastrado com este identificador")
+	// ErrAdvisoryInUse: há entradas de changelog ligadas ao boletim.
+	ErrAdvisoryInUse = errors.New("boletim ainda ligado a entradas de changelog")
+)
+
+type AdvisoryService struct {
+	repo     repository.AdvisoryRepository
+	products repository.ProductRepository
+}
+
+func NewAdvisoryService(repo repository.AdvisoryRepository, products repository.ProductRepository) *AdvisoryService {
+	return &AdvisoryService{repo: repo, products: products}
+}
+
+type AdvisoryInput struct {
+	ProductID    uint
+	Identifier   string
+	Title        string
+	Description  string
+	CVSSVector   string
+	CVSSScore    *float64 // obrigatório só sem vetor; com vetor, precisa bater
+	AffectedFrom string
+	AffectedTo   string
+	FixedVersion string
+	DisclosedAt  *time.Time
+}
+
+type AdvisoryQuery struct {
+	Product  string // ID ou nome do produto
+	Severity string
+	Version  string // só boletins que afetam esta versão
+	Q        string
+}
+
+func (s *AdvisoryService) List(q AdvisoryQuery) ([]models.SecurityAdvisory, error) {
+	f, err := advisoryFilter(q)
+	if err != nil {
+		return nil, err
+	}
+	if q.Product != "" {
+		p, err := resolveProduct(s.products, q.Product)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return []models.SecurityAdvisory{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		f.ProductID = p.ID
+	}
+	return s.find(f, q.Version)
+}
+
+// ForProduct lista os boletins do produto (404 se o produto não existir).
+func (s *AdvisoryService) ForProduct(productRef string, q AdvisoryQuery) ([]models.SecurityAdvisory, error) {
+	f, err := advisoryFilter(q)
+	if err != nil {
+		return nil, err
+	}
+	p, err := resolveProduct(s.products, productRef)
+	if err != nil {
+		return nil, err
+	}
+	f.ProductID = p.ID
+	return s.find(f, q.Version)
+}
+
+func advisoryFilter(q AdvisoryQuery) (repository.AdvisoryFilter, error) {
+	f := repository.AdvisoryFilter{Severity: strings.ToLower(strings.TrimSpace(q.Severity)), Q: q.Q}
+	if f.Severity != "" && !cvss.ValidSeverity(f.Severity) {
+		return f, invalidf("severity inválida: use none|low|medium|high|critical")
+	}
+	if q.Version != "" && !version.Valid(q.Version) {
+		return f, invalidf("version inválida: %s", q.Version)
+	}
+	return f, nil
+}
+
+// find aplica o filtro e, se v != "", mantém só os boletins que afetam v.
+func (s *AdvisoryService) find(f repository.AdvisoryFilter, v string) ([]models.SecurityAdvisory, error) {
+	list, err := s.repo.List(f)
+	if err != nil || v == "" {
+		return list, err
+	}
+	out := make([]models.SecurityAdvisory, 0, len(list))
+	for i := range list {
+		if advisoryAffects(&list[i], v) {
+			out = append(out, list[i])
+		}
+	}
+	return out, nil
+}
+
+// Get devolve o boletim e os releases cujas entradas apontam para ele.
+func (s *AdvisoryService) Get(id uint) (*models.SecurityAdvisory, []models.Release, error) {
+	a, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	rels, err := s.repo.Releases(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	return a, rels, nil
+}
+
+func (s *AdvisoryService) Create(in AdvisoryInput) (*models.SecurityAdvisory, error) {
+	a := &models.SecurityAdvisory{}
+	if err := s.fill(a, in); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(a); err != nil {
+		if IsUniqueViolation(err) {
+			return nil, ErrAdvisoryExists
+		}
+		return nil, err
+	}
+	return s.repo.GetByID(a.ID)
+}
+
+func (s *AdvisoryService) Update(id uint, in AdvisoryInput) (*models.SecurityAdvisory, error) {
+	a, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if in.ProductID != a.ProductID {
+		n, err := s.repo.CountEntries(id)
+		if err != nil {
+			return nil, err
+		}
+		if n > 0 {
+			return nil, invalidf("o produto do boletim não pode mudar enquanto houver entradas ligadas a ele")
+		}
+	}
+	if err := s.fill(a, in); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(a); err != nil {
+		if IsUniqueViolation(err) {
+			return nil, ErrAdvisoryExists
+		}
+		return nil, err
+	}
+	return s.repo.GetByID(id)
+}
+
+func (s *AdvisoryService) Delete(id uint) error {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return err
+	}
+	n, err := s.repo.CountEntries(id)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return ErrAdvisoryInUse
+	}
+	return s.repo.Delete(id)
+}
+
+func (s *AdvisoryService) fill(a *models.SecurityAdvisory, in AdvisoryInput) error {
+	ident := strings.ToUpper(strings.TrimSpace(in.Identifier))
+	if ident == "" {
+		return invalidf("identificador é obrigatório (CVE ou ID interno)")
+	}
+	title := strings.TrimSpace(in.Title)
+	if title == "" {
+		return invalidf("título é obrigatório")
+	}
+	if in.ProductID == 0 {
+		return invalidf("produto é obrigatório (productId)")
+	}
+	if _, err := s.products.GetByID(in.ProductID); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return invalidf("produto %d não encontrado", in.ProductID)
+		}
+		return err
+	}
+
+	vector := strings.TrimSpace(in.CVSSVector)
+	var score float64
+	switch {
+	case vector != "":
+		v, err := cvss.Parse(vector)
+		if err != nil {
+			return invalidf("cvssVector: %v", err)
+		}
+		score = v.BaseScore()
+		if in.CVSSScore != nil && *in.CVSSScore != score {
+			return invalidf("cvssScore (%.1f) não confere com o vetor (%.1f)", *in.CVSSScore, score)
+		}
+	case in.CVSSScore != nil:
+		score = *in.CVSSScore
+		if score < 0 || score > 10 {
+			return invalidf("cvssScore deve estar entre 0.0 e 10.0")
+		}
+	default:
+		return invalidf("informe cvssVector ou cvssScore")
+	}
+
+	from, to, fixed := strings.TrimSpace(in.AffectedFrom), strings.TrimSpace(in.AffectedTo), strings.TrimSpace(in.FixedVersion)
+	if to == "" {
+		return invalidf("affectedTo é obrigatório (última versão afetada)")
+	}
+	for _, f := range []struct{ name, v string }{{"affectedFrom", from}, {"affectedTo", to}, {"fixedVersion", fixed}} {
+		if f.v != "" && !version.Valid(f.v) {
+			return invalidf("%s: versão inválida: %s", f.name, f.v)
+		}
+	}
+	if from != "" && version.Compare(from, to) > 0 {
+		return invalidf("affectedFrom (%s) deve ser menor ou igual a affectedTo (%s)", from, to)
+	}
+	if fixed != "" && version.Compare(fixed, to) <= 0 {
+		return invalidf("fixedVersion (%s) deve ser maior que affectedTo (%s)", fixed, to)
+	}
+
+	a.ProductID, a.Product = in.ProductID, nil
+	a.Identifier, a.Title, a.Description = ident, title, strings.TrimSpace(in.Description)
+	a.CVSSVector, a.CVSSScore, a.Severity = vector, score, cvss.Severity(score)
+	a.AffectedFrom, a.AffectedTo, a.FixedVersion = from, to, fixed
+	a.DisclosedAt = in.DisclosedAt
+	return nil
+}
+
+// advisoryAffects informa se a versão v está na faixa afetada do boletim.
+func advisoryAffects(a *models.SecurityAdvisory, v string) bool {
+	if a.AffectedFrom != "" && version.Compare(v, a.AffectedFrom) < 0 {
+		return false
+	}
+	return version.Compare(v, a.AffectedTo) <= 0
+}
+
+// checkAdvisories exige que só entradas de Segurança apontem para um
+// boletim e que o boletim seja do mesmo produto do release.
+func (s *ReleaseService) checkAdvisories(productID *uint, entries []models.ChangelogEntry) error {
+	var ids []uint
+	for i, e := range entries {
+		if e.AdvisoryID == nil {
+			continue
+		}
+		if e.Classification != models.ClassificationSeguranca {
+			return invalidf("entrada %d: só entradas %q podem apontar para um boletim de segurança", i+1, models.ClassificationSeguranca)
+		}
+		ids = append(ids, *e.AdvisoryID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	list, err := s.advisories.GetByIDs(ids)
+	if err != nil {
+		return err
+	}
+	byID := make(map[uint]*models.SecurityAdvisory, len(list))
+	for i := range list {
+		byID[list[i].ID] = &list[i]
+	}
+	for i, e := range entries {
+		if e.AdvisoryID == nil {
+			continue
+		}
+		a := byID[*e.AdvisoryID]
+		if a == nil {
+			return invalidf("entrada %d: boletim de segurança %d não encontrado", i+1, *e.AdvisoryID)
+		}
+		if productID == nil || a.ProductID != *productID {
+			return invalidf("entrada %d: boletim %s é de outro produto", i+1, a.Identifier)
+		}
+	}
+	return nil
+}
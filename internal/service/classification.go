@@ -0,0 +1,170 @@
+// internal/service/classification.go
+package service
+
+import (
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/repository"
+)
+
+var (
+	ErrClassificationExists = errors.New("classificação já cadastrada")
+	// ErrClassificationInUse: há entradas de changelog usando a classificação.
+	ErrClassificationInUse = errors.New("classificação ainda usada por entradas de changelog")
+)
+
+var colorPattern = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+type ClassificationService struct {
+	repo repository.ClassificationRepository
+}
+
+func NewClassificationService(repo repository.ClassificationRepository) *ClassificationService {
+	return &ClassificationService{repo: repo}
+}
+
+type ClassificationInput struct {
+	Key       string
+	Labels    map[string]string
+	Color     string
+	SortOrder int
+}
+
+func (s *ClassificationService) List() ([]models.Classification, error) {
+	return s.repo.List()
+}
+
+func (s *ClassificationService) Create(in ClassificationInput) (*models.Classification, error) {
+	key := strings.Join(strings.Fields(in.Key), " ")
+	if key == "" {
+		return nil, invalidf("chave da classificação é obrigatória")
+	}
+	list, err := s.repo.List()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range list {
+		if models.NormalizeKey(c.Key) == models.NormalizeKey(key) {
+			return nil, ErrClassificationExists
+		}
+	}
+	c := &models.Classification{Key: key}
+	if err := fillClassification(c, in); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(c); err != nil {
+		if IsUniqueViolation(err) {
+			return nil, ErrClassificationExists
+		}
+		return nil, err
+	}
+	return c, nil
+}
+
+// Update troca rótulos, cor e ordem; a chave é fixa porque está gravada
+// nas entradas.
+func (s *ClassificationService) Update(id uint, in ClassificationInput) (*models.Classification, error) {
+	c, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if k := strings.TrimSpace(in.Key); k != "" && models.NormalizeKey(k) != models.NormalizeKey(c.Key) {
+		return nil, invalidf("a chave da classificação não pode ser alterada")
+	}
+	if err := fillClassification(c, in); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *ClassificationService) Delete(id uint) error {
+	c, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	n, err := s.repo.CountEntries(c.Key)
+	if err != nil {
+		return err
+	}
+	if n > 0 {
+		return ErrClassificationInUse
+	}
+	return s.repo.Delete(id)
+}
+
+func fillClassification(c *models.Classification, in ClassificationInput) error {
+	labels := make(map[string]string, len(in.Labels))
+	for lang, label := range in.Labels {
+		lang, label = strings.TrimSpace(lang), strings.TrimSpace(label)
+		if lang == "" || label == "" {
+			return invalidf("rótulos precisam de idioma e texto")
+		}
+		labels[lang] = label
+	}
+	if len(labels) == 0 {
+		return invalidf("informe ao menos um rótulo (ex.: {\"pt-BR\": \"Correção\"})")
+	}
+	color := strings.TrimSpace(in.Color)
+	if color != "" && !colorPattern.MatchString(color) {
+		return invalidf("cor inválida: use #RRGGBB")
+	}
+	c.Labels, c.Color, c.SortOrder = labels, strings.ToUpper(color), in.SortOrder
+	return nil
+}
+
+// checkClassifications exige que toda entrada use uma classificação
+// cadastrada e grava a chave canônica (sem diferenciar maiúsculas/espaços).
+func (s *ReleaseService) checkClassifications(entries []models.ChangelogEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	list, err := s.classifications.List()
+	if err != nil {
+		return err
+	}
+	byKey := make(map[string]string, len(list))
+	for _, c := range list {
+		byKey[models.NormalizeKey(c.Key)] = c.Key
+	}
+	for i := range entries {
+		key, ok := byKey[models.NormalizeKey(string(entries[i].Classification))]
+		if !ok {
+			return invalidf("entrada %d: classificação desconhecida: %q", i+1, entries[i].Classification)
+		}
+		entries[i].Classification = models.EntryClassification(key)
+	}
+	return nil
+}
+
+// sortClassifications ordena chaves pela SortOrder do cadastro; chaves
+// sem cadastro vão ao fim, em ordem alfabética.
+func (s *ReleaseService) sortClassifications(keys []models.EntryClassification) error {
+	list, err := s.classifications.List()
+	if err != nil {
+		return err
+	}
+	rank := make(map[models.EntryClassification]int, len(list))
+	for i, c := range list {
+		rank[models.EntryClassification(c.Key)] = i
+	}
+	sort.SliceStable(keys, func(i, j int) bool {
+		ri, oki := rank[keys[i]]
+		rj, okj := rank[keys[j]]
+		switch {
+		case oki && okj:
+			return ri < rj
+		case oki != okj:
+			return oki
+		default:
+			return keys[i] < keys[j]
+		}
+	})
+	return nil
+}
@@ -0,0 +1,81 @@
+// internal/service/compatibility.go
+package service
+
+import (
+	"sort"
+	"time"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+)
+
+type CompatibilityCell struct {
+	Present bool
+	Version string
+	Updated bool
+}
+
+type CompatibilityRow struct {
+	ReleaseID   uint
+	Version     string
+	ReleaseDate time.Time
+	Status      models.FirmwareStatus
+	Cells       []CompatibilityCell // alinhadas com CompatibilityMatrix.Modules
+}
+
+type CompatibilityMatrix struct {
+	Product *models.Product
+	Modules []string
+	Rows    []CompatibilityRow
+}
+
+// CompatibilityMatrix monta uma linha por release (versão mais nova
+// primeiro) e uma coluna por módulo. As colunas seguem o cadastro de
+// módulos do produto; módulos que só aparecem nos releases vêm depois.
+func (s *ReleaseService) CompatibilityMatrix(productRef string) (*CompatibilityMatrix, error) {
+	p, err := resolveProduct(s.products, productRef)
+	if err != nil {
+		return nil, err
+	}
+	reg, err := s.products.ListModules(p.ID)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.List(ReleaseQuery{ProductID: p.ID, Sort: SortVersion})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &CompatibilityMatrix{Product: p}
+	col := map[string]int{}
+	for _, m := range reg {
+		col[m.Name] = len(out.Modules)
+		out.Modules = append(out.Modules, m.Name)
+	}
+	var extra []string
+	for _, r := range list {
+		for _, m := range r.Modules {
+			if _, ok := col[m.Module]; !ok {
+				col[m.Module] = -1
+				extra = append(extra, m.Module)
+			}
+		}
+	}
+	sort.Strings(extra)
+	for _, name := range extra {
+		col[name] = len(out.Modules)
+		out.Modules = append(out.Modules, name)
+	}
+
+	out.Rows = make([]CompatibilityRow, 0, len(list))
+	for _, r := range list {
+		row := CompatibilityRow{
+			ReleaseID: r.ID, Version: r.Version, ReleaseDate: r.ReleaseDate, Status: r.Status,
+			Cells: make([]CompatibilityCell, len(out.Modules)),
+		}
+		for _, m := range r.Modules {
+			row.Cells[col[m.Module]] = CompatibilityCell{Present: true, Version: m.Version, Updated: m.Updated}
+		}
+		out.Rows = append(out.Rows, row)
+	}
+	return out, nil
+}
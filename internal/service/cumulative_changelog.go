@@ -0,0 +1,94 @@
+// internal/service/cumulative_changelog.go
+package service
+
+import (
+	"sort"
+	"time"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/version"
+)
+
+type CumulativeItem struct {
+	ReleaseID   uint
+	Version     string
+	ReleaseDate time.Time
+	ItemOrder   int
+	Observation string
+}
+
+type CumulativeGroup struct {
+	Classification models.EntryClassification
+	Items          []CumulativeItem
+}
+
+type CumulativeChangelog struct {
+	Versions []string // versões incluídas, da mais antiga para a mais nova
+	Groups   []CumulativeGroup
+}
+
+// CumulativeChangelog junta as entradas de todos os releases do produto com
+// versão em (from, to], agrupadas por classificação.
+func (s *ReleaseService) CumulativeChangelog(product, from, to string) (*CumulativeChangelog, error) {
+	if product == "" || from == "" || to == "" {
+		return nil, invalidf("product, from e to são obrigatórios")
+	}
+	vf, err := version.Parse(from)
+	if err != nil {
+		return nil, invalidf("from: %v", err)
+	}
+	vt, err := version.Parse(to)
+	if err != nil {
+		return nil, invalidf("to: %v", err)
+	}
+	if vf.Compare(vt) >= 0 {
+		return nil, invalidf("from (%s) deve ser menor que to (%s)", from, to)
+	}
+
+	list, err := s.List(ReleaseQuery{Product: product})
+	if err != nil {
+		return nil, err
+	}
+
+	var in []models.Release
+	for _, r := range list {
+		v, err := version.Parse(r.Version)
+		if err != nil {
+			continue
+		}
+		if v.Compare(vf) > 0 && v.Compare(vt) <= 0 {
+			in = append(in, r)
+		}
+	}
+	sort.SliceStable(in, func(i, j int) bool {
+		return version.Compare(in[i].Version, in[j].Version) < 0
+	})
+
+	out := &CumulativeChangelog{Versions: make([]string, 0, len(in))}
+	byClass := map[models.EntryClassification][]CumulativeItem{}
+	for _, r := range in {
+		out.Versions = append(out.Versions, r.Version)
+		for _, e := range r.Entries {
+			byClass[e.Classification] = append(byClass[e.Classification], CumulativeItem{
+				ReleaseID:   r.ID,
+				Version:     r.Version,
+				ReleaseDate: r.ReleaseDate,
+				ItemOrder:   e.ItemOrder,
+				Observation: e.Observation,
+			})
+		}
+	}
+
+	// grupos na ordem do cadastro de classificações
+	keys := make([]models.EntryClassification, 0, len(byClass))
+	for cl := range byClass {
+		keys = append(keys, cl)
+	}
+	if err := s.sortClassifications(keys); err != nil {
+		return nil, err
+	}
+	for _, cl := range keys {
+		out.Groups = append(out.Groups, CumulativeGroup{Classification: cl, Items: byClass[cl]})
+	}
+	return out, nil
+}
@@ -0,0 +1,8 @@
+package service
+
+var (
+	IssueOpenIn      = issueOpenIn
+	VerifyLink       = verifyLink
+	CheckSignedLinks = checkSignedLinks
+	UpgradeSteps     = upgradeSteps
+)
@@ -0,0 +1,267 @@
+// internal/service/integrity.go
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/repository"
+)
+
+var ErrIntegrityRunning = errors.New("verificação de integridade já em andamento")
+
+// FileDigest é o resultado de baixar um arquivo: status HTTP e, quando
+// 200, o SHA-256 (hex) e o tamanho do corpo.
+type FileDigest struct {
+	Status int
+	SHA256 string
+	Size   int64
+}
+
+// FetchFile baixa a URL e calcula o digest; erro = falha de rede.
+type FetchFile func(ctx context.Context, url string) (FileDigest, error)
+
+type IntegrityService struct {
+	repo    repository.IntegrityRepository
+	running sync.Mutex
+	queue   chan struct{} // rodadas pedidas via API, atendidas pelo worker
+
+	mu     sync.Mutex
+	status IntegrityStatus
+}
+
+func NewIntegrityService(repo repository.IntegrityRepository) *IntegrityService {
+	return &IntegrityService{repo: repo, queue: make(chan struct{}, 1), status: IntegrityStatus{State: IntegrityIdle}}
+}
+
+type IntegrityState string
+
+const (
+	IntegrityIdle    IntegrityState = "idle"
+	IntegrityQueued  IntegrityState = "queued"
+	IntegrityRunning IntegrityState = "running"
+)
+
+// IntegrityStatus é a situação da verificação: se há rodada pedida ou em
+// andamento e o resultado da última que terminou.
+type IntegrityStatus struct {
+	State     IntegrityState
+	QueuedAt  *time.Time
+	StartedAt *time.Time
+	Last      *IntegrityReport // última rodada (parcial se interrompida)
+	LastError string
+}
+
+// IntegrityReport resume uma rodada de verificação.
+type IntegrityReport struct {
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Checked    int
+	Counts     map[models.VerificationResult]int
+	Problems   []models.LinkVerification // tudo que não deu ok
+}
+
+// IntegritySummary é a situação atual: a última conferência de cada link.
+type IntegritySummary struct {
+	Links         int // links sob a base pública
+	Unchecked     int // nunca conferidos (ou com URL trocada desde então)
+	LastCheckedAt *time.Time
+	Counts        map[models.VerificationResult]int
+	Problems      []models.LinkVerification
+}
+
+func newResultCounts() map[models.VerificationResult]int {
+	return map[models.VerificationResult]int{
+		models.VerificationOK: 0, models.VerificationMismatch: 0, models.VerificationChanged: 0,
+		models.VerificationMissing: 0, models.VerificationError: 0,
+	}
+}
+
+// Enqueue pede uma rodada ao worker (ver Requests) e devolve a situação.
+// Com uma rodada já pedida ou em andamento, devolve ErrIntegrityRunning.
+func (s *IntegrityService) Enqueue() (IntegrityStatus, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.status.State != IntegrityIdle {
+		return s.status, ErrIntegrityRunning
+	}
+	select {
+	case s.queue <- struct{}{}:
+	default: // já há um pedido na fila
+	}
+	now := time.Now()
+	s.status.State, s.status.QueuedAt, s.status.StartedAt = IntegrityQueued, &now, nil
+	return s.status, nil
+}
+
+// Requests entrega os pedidos feitos por Enqueue; quem escuta chama Run.
+func (s *IntegrityService) Requests() <-chan struct{} { return s.queue }
+
+func (s *IntegrityService) Status() IntegrityStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.status
+}
+
+// Run baixa de novo cada link sob urlPrefix, compara o SHA-256 e grava o
+// resultado no histórico do link. Só uma rodada por vez; um pedido na fila
+// é atendido por esta rodada. Se ctx for cancelado no meio, devolve o
+// relatório parcial junto com ctx.Err().
+func (s *IntegrityService) Run(ctx context.Context, urlPrefix string, fetch FetchFile) (*IntegrityReport, error) {
+	if !s.running.TryLock() {
+		return nil, ErrIntegrityRunning
+	}
+	defer s.running.Unlock()
+
+	select {
+	case <-s.queue:
+	default:
+	}
+	now := time.Now()
+	s.mu.Lock()
+	s.status.State, s.status.StartedAt = IntegrityRunning, &now
+	s.mu.Unlock()
+
+	rep, err := s.run(ctx, urlPrefix, fetch)
+
+	s.mu.Lock()
+	s.status = IntegrityStatus{State: IntegrityIdle, StartedAt: &now, Last: rep}
+	if err != nil {
+		s.status.LastError = err.Error()
+	}
+	s.mu.Unlock()
+	return rep, err
+}
+
+func (s *IntegrityService) run(ctx context.Context, urlPrefix string, fetch FetchFile) (*IntegrityReport, error) {
+	links, err := s.repo.Links(urlPrefix)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := s.latestByLink()
+	if err != nil {
+		return nil, err
+	}
+	rep := &IntegrityReport{StartedAt: time.Now(), Counts: newResultCounts()}
+	for i := range links {
+		if err := ctx.Err(); err != nil {
+			rep.FinishedAt = time.Now()
+			return rep, err
+		}
+		l := &links[i]
+		if !strings.HasPrefix(l.URL, urlPrefix) {
+			continue
+		}
+		d, ferr := fetch(ctx, l.URL)
+		v := verifyLink(l, latest[l.ID], d, ferr, time.Now())
+		if err := s.repo.Create(&v); err != nil {
+			rep.FinishedAt = time.Now()
+			return rep, err
+		}
+		rep.Checked++
+		rep.Counts[v.Result]++
+		if v.Result != models.VerificationOK {
+			rep.Problems = append(rep.Problems, v)
+		}
+	}
+	rep.FinishedAt = time.Now()
+	return rep, nil
+}
+
+// verifyLink classifica uma conferência. Sem checksum do upload (links
+// antigos), a referência é o primeiro hash obtido para a mesma URL.
+func verifyLink(l *models.FirmwareLink, prev *models.LinkVerification, d FileDigest, ferr error, at time.Time) models.LinkVerification {
+	v := models.LinkVerification{
+		LinkID: l.ID, ReleaseID: l.ReleaseID, URL: l.URL,
+		ExpectedSHA256: l.SHA256, ExpectedSize: l.SizeBytes,
+		ActualSHA256: d.SHA256, ActualSize: d.Size, HTTPStatus: d.Status,
+		CheckedAt: at,
+	}
+	if v.ExpectedSHA256 == "" && prev != nil && prev.URL == l.URL {
+		v.ExpectedSHA256, v.ExpectedSize = prev.ExpectedSHA256, prev.ExpectedSize
+		if v.ExpectedSHA256 == "" {
+			v.ExpectedSHA256, v.ExpectedSize = prev.ActualSHA256, prev.ActualSize
+		}
+	}
+	switch {
+	case ferr != nil:
+		v.Result, v.Error = models.VerificationError, truncate(ferr.Error(), 500)
+	case d.Status == http.StatusNotFound || d.Status == http.StatusGone:
+		v.Result = models.VerificationMissing
+	case d.Status != http.StatusOK:
+		v.Result, v.Error = models.VerificationError, fmt.Sprintf("resposta HTTP %d", d.Status)
+	case v.ExpectedSHA256 == "" || d.SHA256 == v.ExpectedSHA256:
+		v.Result = models.VerificationOK
+	case l.SHA256 != "":
+		v.Result = models.VerificationMismatch
+	default:
+		v.Result = models.VerificationChanged
+	}
+	return v
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return strings.ToValidUTF8(s[:n], "")
+}
+
+func (s *IntegrityService) latestByLink() (map[uint]*models.LinkVerification, error) {
+	list, err := s.repo.Latest()
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[uint]*models.LinkVerification, len(list))
+	for i := range list {
+		out[list[i].LinkID] = &list[i]
+	}
+	return out, nil
+}
+
+// Summary resume a última conferência de cada link sob urlPrefix.
+func (s *IntegrityService) Summary(urlPrefix string) (*IntegritySummary, error) {
+	links, err := s.repo.Links(urlPrefix)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := s.latestByLink()
+	if err != nil {
+		return nil, err
+	}
+	out := &IntegritySummary{Counts: newResultCounts()}
+	for _, l := range links {
+		if !strings.HasPrefix(l.URL, urlPrefix) {
+			continue
+		}
+		out.Links++
+		v, ok := latest[l.ID]
+		if !ok || v.URL != l.URL {
+			out.Unchecked++
+			continue
+		}
+		out.Counts[v.Result]++
+		if v.Result != models.VerificationOK {
+			out.Problems = append(out.Problems, *v)
+		}
+		if out.LastCheckedAt == nil || v.CheckedAt.After(*out.LastCheckedAt) {
+			at := v.CheckedAt
+			out.LastCheckedAt = &at
+		}
+	}
+	return out, nil
+}
+
+// History lista as conferências de um link do release, mais recentes antes.
+func (s *IntegrityService) History(releaseID, linkID uint, limit int) ([]models.LinkVerification, error) {
+	if _, err := s.repo.GetLink(releaseID, linkID); err != nil {
+		return nil, err
+	}
+	return s.repo.History(linkID, limit)
+}
@@ -0,0 +1,75 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/service"
+)
+
+func TestVerifyLink(t *testing.T) {
+	const url = "https://files.example.com/firmware/app.bin"
+	uploaded := &models.FirmwareLink{ID: 7, ReleaseID: 3, URL: url, SHA256: "aaa", SizeBytes: 10}
+	legacy := &models.FirmwareLink{ID: 8, ReleaseID: 3, URL: url}
+	ok := func(sum string) service.FileDigest { return service.FileDigest{Status: 200, SHA256: sum, Size: 10} }
+	first := &models.LinkVerification{LinkID: 8, URL: url, ActualSHA256: "bbb", ActualSize: 10}
+
+	for _, tc := range []struct {
+		name     string
+		link     *models.FirmwareLink
+		prev     *models.LinkVerification
+		d        service.FileDigest
+		ferr     error
+		want     models.VerificationResult
+		expected string
+	}{
+		{"ok", uploaded, nil, ok("aaa"), nil, models.VerificationOK, "aaa"},
+		{"mismatch", uploaded, nil, ok("ccc"), nil, models.VerificationMismatch, "aaa"},
+		{"missing 404", uploaded, nil, service.FileDigest{Status: 404}, nil, models.VerificationMissing, "aaa"},
+		{"missing 410", uploaded, nil, service.FileDigest{Status: 410}, nil, models.VerificationMissing, "aaa"},
+		{"erro HTTP", uploaded, nil, service.FileDigest{Status: 503}, nil, models.VerificationError, "aaa"},
+		{"erro de rede", uploaded, nil, service.FileDigest{}, errors.New("timeout"), models.VerificationError, "aaa"},
+		{"legado sem referência", legacy, nil, ok("bbb"), nil, models.VerificationOK, ""},
+		{"legado igual à primeira", legacy, first, ok("bbb"), nil, models.VerificationOK, "bbb"},
+		{"legado mudou", legacy, first, ok("ccc"), nil, models.VerificationChanged, "bbb"},
+		{"referência herdada da anterior", legacy, &models.LinkVerification{URL: url, ExpectedSHA256: "ddd", ActualSHA256: "eee"}, ok("eee"), nil, models.VerificationChanged, "ddd"},
+		{"anterior de outra URL", legacy, &models.LinkVerification{URL: url + ".old", ActualSHA256: "bbb"}, ok("ccc"), nil, models.VerificationOK, ""},
+		{"upload ignora a anterior", uploaded, first, ok("aaa"), nil, models.VerificationOK, "aaa"},
+	} {
+		at := time.Now()
+		v := service.VerifyLink(tc.link, tc.prev, tc.d, tc.ferr, at)
+		if v.Result != tc.want {
+			t.Errorf("%s: result = %s, esperava %s", tc.name, v.Result, tc.want)
+		}
+		if v.ExpectedSHA256 != tc.expected {
+			t.Errorf("%s: expected = %q, esperava %q", tc.name, v.ExpectedSHA256, tc.expected)
+		}
+		if v.LinkID != tc.link.ID || v.URL != tc.link.URL || !v.CheckedAt.Equal(at) {
+			t.Errorf("%s: identificação errada: %+v", tc.name, v)
+		}
+		if (v.Result == models.VerificationError) != (v.Error != "") {
+			t.Errorf("%s: error = %q com result %s", tc.name, v.Error, v.Result)
+		}
+	}
+}
+
+func TestIntegrityEnqueue(t *testing.T) {
+	s := service.NewIntegrityService(nil)
+	if st := s.Status(); st.State != service.IntegrityIdle {
+		t.Fatalf("state inicial = %s", st.State)
+	}
+	st, err := s.Enqueue()
+	if err != nil || st.State != service.IntegrityQueued || st.QueuedAt == nil {
+		t.Fatalf("Enqueue = %+v, %v", st, err)
+	}
+	if _, err := s.Enqueue(); !errors.Is(err, service.ErrIntegrityRunning) {
+		t.Fatalf("segundo Enqueue: err = %v", err)
+	}
+	select {
+	case <-s.Requests():
+	default:
+		t.Fatal("pedido não chegou ao worker")
+	}
+}
@@ -0,0 +1,171 @@
+// internal/service/known_issue.go
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/repository"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/version"
+)
+
+type KnownIssueService struct {
+	repo     repository.KnownIssueRepository
+	releases repository.ReleaseRepository
+	products repository.ProductRepository
+}
+
+func NewKnownIssueService(repo repository.KnownIssueRepository, releases repository.ReleaseRepository, products repository.ProductRepository) *KnownIssueService {
+	return &KnownIssueService{repo: repo, releases: releases, products: products}
+}
+
+type KnownIssueInput struct {
+	Title            string
+	Description      string
+	Severity         string
+	Workaround       string
+	FixedInReleaseID *uint
+}
+
+// Open devolve, por ID de release, os problemas em aberto naquela versão:
+// encontrados nela ou antes e ainda não corrigidos até ela.
+func (s *KnownIssueService) Open(list ...*models.Release) (map[uint][]models.KnownIssue, error) {
+	var productIDs []uint
+	seen := map[uint]bool{}
+	for _, r := range list {
+		if r.ProductID != nil && !seen[*r.ProductID] {
+			seen[*r.ProductID] = true
+			productIDs = append(productIDs, *r.ProductID)
+		}
+	}
+	issues, err := s.repo.ListByProducts(productIDs)
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[uint][]models.KnownIssue, len(list))
+	for _, r := range list {
+		if r.ProductID == nil {
+			continue
+		}
+		for _, k := range issues {
+			if k.ProductID == *r.ProductID && issueOpenIn(&k, r) {
+				out[r.ID] = append(out[r.ID], k)
+			}
+		}
+	}
+	return out, nil
+}
+
+// issueOpenIn: o release de origem e o da correção na lixeira contam como
+// inexistentes (o problema some ou volta a ficar em aberto).
+func issueOpenIn(k *models.KnownIssue, r *models.Release) bool {
+	if k.Release == nil {
+		return false
+	}
+	if k.ReleaseID != r.ID && version.Compare(k.Release.Version, r.Version) > 0 {
+		return false
+	}
+	if k.FixedIn == nil {
+		return true
+	}
+	return k.FixedIn.ID != r.ID && version.Compare(r.Version, k.FixedIn.Version) < 0
+}
+
+// ForRelease lista os problemas em aberto na versão do release.
+func (s *KnownIssueService) ForRelease(id uint) ([]models.KnownIssue, error) {
+	rel, err := s.releases.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	open, err := s.Open(rel)
+	if err != nil {
+		return nil, err
+	}
+	return open[id], nil
+}
+
+// ForProduct lista todos os problemas do produto, corrigidos ou não.
+func (s *KnownIssueService) ForProduct(productRef string) ([]models.KnownIssue, error) {
+	p, err := resolveProduct(s.products, productRef)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListByProducts([]uint{p.ID})
+}
+
+func (s *KnownIssueService) Create(releaseID uint, in KnownIssueInput) (*models.KnownIssue, error) {
+	rel, err := s.releases.GetByID(releaseID)
+	if err != nil {
+		return nil, err
+	}
+	if rel.ProductID == nil {
+		return nil, invalidf("release sem produto cadastrado")
+	}
+	k := &models.KnownIssue{ProductID: *rel.ProductID, ReleaseID: rel.ID, Release: rel}
+	if err := s.fill(k, in); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(k); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(k.ID)
+}
+
+// Update troca os dados do problema; o release de origem é fixo.
+func (s *KnownIssueService) Update(id uint, in KnownIssueInput) (*models.KnownIssue, error) {
+	k, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if k.Release == nil {
+		return nil, invalidf("o release de origem deste problema está na lixeira")
+	}
+	if err := s.fill(k, in); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(k); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(id)
+}
+
+func (s *KnownIssueService) Delete(id uint) error {
+	return s.repo.Delete(id)
+}
+
+func (s *KnownIssueService) fill(k *models.KnownIssue, in KnownIssueInput) error {
+	title := strings.TrimSpace(in.Title)
+	if title == "" {
+		return invalidf("título é obrigatório")
+	}
+	sev := models.IssueSeverity(strings.ToLower(strings.TrimSpace(in.Severity)))
+	if sev == "" {
+		sev = models.IssueSeverityMedium
+	}
+	if !sev.Valid() {
+		return invalidf("severity inválida: use low|medium|high|critical")
+	}
+	k.FixedInReleaseID, k.FixedIn = nil, nil
+	if in.FixedInReleaseID != nil {
+		fixed, err := s.releases.GetByID(*in.FixedInReleaseID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return invalidf("release da correção %d não encontrado", *in.FixedInReleaseID)
+		}
+		if err != nil {
+			return err
+		}
+		if fixed.ProductID == nil || *fixed.ProductID != k.ProductID {
+			return invalidf("o release da correção precisa ser do mesmo produto")
+		}
+		if version.Compare(fixed.Version, k.Release.Version) <= 0 {
+			return invalidf("o release da correção (%s) precisa ser posterior a %s", fixed.Version, k.Release.Version)
+		}
+		k.FixedInReleaseID = &fixed.ID
+	}
+	k.Title, k.Severity = title, sev
+	k.Description, k.Workaround = strings.TrimSpace(in.Description), strings.TrimSpace(in.Workaround)
+	return nil
+}
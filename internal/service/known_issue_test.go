@@ -0,0 +1,43 @@
+package service_test
+
+import (
+	"testing"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/service"
+)
+
+func TestIssueOpenIn(t *testing.T) {
+	rel := func(id uint, v string) *models.Release {
+		return &models.Release{ID: id, Version: v}
+	}
+	v110, v120, v130 := rel(1, "1.1.0"), rel(2, "1.2.0"), rel(3, "1.3.0")
+	issue := func(found, fixed *models.Release) *models.KnownIssue {
+		k := &models.KnownIssue{Release: found, FixedIn: fixed}
+		if found != nil {
+			k.ReleaseID = found.ID
+		}
+		return k
+	}
+
+	for _, tc := range []struct {
+		name  string
+		issue *models.KnownIssue
+		in    *models.Release
+		want  bool
+	}{
+		{"origem na lixeira", issue(nil, nil), v120, false},
+		{"no próprio release", issue(v120, nil), v120, true},
+		{"encontrado antes, sem correção", issue(v110, nil), v130, true},
+		{"encontrado depois", issue(v130, nil), v120, false},
+		{"corrigido depois", issue(v110, v130), v120, true},
+		{"no release da correção", issue(v110, v130), v130, false},
+		{"depois da correção", issue(v110, v120), v130, false},
+		{"origem com correção posterior", issue(v110, v120), v110, true},
+		{"mesma versão canônica", issue(v110, nil), rel(9, "1.1.0"), true},
+	} {
+		if got := service.IssueOpenIn(tc.issue, tc.in); got != tc.want {
+			t.Errorf("%s: got %v, esperava %v", tc.name, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// internal/service/product.go
+package service
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/repository"
+)
+
+type ProductService struct {
+	repo repository.ProductRepository
+}
+
+func NewProductService(repo repository.ProductRepository) *ProductService {
+	return &ProductService{repo: repo}
+}
+
+/* ===== Categorias ===== */
+
+func (s *ProductService) ListCategories() ([]models.ProductCategory, error) {
+	return s.repo.ListCategories()
+}
+
+func (s *ProductService) GetCategory(id uint) (*models.ProductCategory, error) {
+	return s.repo.GetCategory(id)
+}
+
+func (s *ProductService) CreateCategory(name string) (*models.ProductCategory, error) {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" {
+		return nil, invalidf("nome da categoria é obrigatório")
+	}
+	c := &models.ProductCategory{Name: name, Key: models.NormalizeKey(name)}
+	if err := s.repo.CreateCategory(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *ProductService) UpdateCategory(id uint, name string) (*models.ProductCategory, error) {
+	c, err := s.repo.GetCategory(id)
+	if err != nil {
+		return nil, err
+	}
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" {
+		return nil, invalidf("nome da categoria é obrigatório")
+	}
+	c.Name, c.Key = name, models.NormalizeKey(name)
+	if err := s.repo.UpdateCategory(c); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (s *ProductService) DeleteCategory(id uint) error {
+	return s.repo.DeleteCategory(id)
+}
+
+/* ===== Produtos ===== */
+
+func (s *ProductService) List(categoryID uint) ([]models.Product, error) {
+	return s.repo.List(categoryID)
+}
+
+func (s *ProductService) Get(id uint) (*models.Product, error) {
+	return s.repo.GetByID(id)
+}
+
+// Resolve aceita o ID numérico ou o nome (sem diferenciar maiúsculas/espaços).
+func (s *ProductService) Resolve(ref string) (*models.Product, error) {
+	return resolveProduct(s.repo, ref)
+}
+
+func (s *ProductService) Create(name string, categoryID *uint) (*models.Product, error) {
+	p := &models.Product{}
+	if err := s.fill(p, name, categoryID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(p); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(p.ID)
+}
+
+func (s *ProductService) Update(id uint, name string, categoryID *uint) (*models.Product, error) {
+	p, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.fill(p, name, categoryID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(p); err != nil {
+		return nil, err
+	}
+	return s.repo.GetByID(id)
+}
+
+func (s *ProductService) Delete(id uint) error {
+	return s.repo.Delete(id)
+}
+
+func (s *ProductService) fill(p *models.Product, name string, categoryID *uint) error {
+	name = strings.Join(strings.Fields(name), " ")
+	if name == "" {
+		return invalidf("nome do produto é obrigatório")
+	}
+	if categoryID != nil {
+		if _, err := s.repo.GetCategory(*categoryID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return invalidf("categoria %d não encontrada", *categoryID)
+			}
+			return err
+		}
+	}
+	p.Name, p.Key = name, models.NormalizeKey(name)
+	p.CategoryID = categoryID
+	p.Category = nil
+	return nil
+}
+
+func resolveProduct(repo repository.ProductRepository, ref string) (*models.Product, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, gorm.ErrRecordNotFound
+	}
+	if id, err := strconv.ParseUint(ref, 10, 64); err == nil {
+		return repo.GetByID(uint(id))
+	}
+	return repo.FindByKey(models.NormalizeKey(ref))
+}
+
+// helper para violação de chave estrangeira (Postgres 23503), ex.: apagar
+// produto que ainda tem releases
+func IsForeignKeyViolation(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "23503")
+}
@@ -0,0 +1,148 @@
+// internal/service/product_module.go
+package service
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/version"
+)
+
+type ProductModuleInput struct {
+	Name           string
+	DisplayName    string
+	VersionPattern string
+	Mandatory      bool
+}
+
+func (s *ProductService) ListModules(productID uint) ([]models.ProductModule, error) {
+	if _, err := s.repo.GetByID(productID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListModules(productID)
+}
+
+func (s *ProductService) CreateModule(productID uint, in ProductModuleInput) (*models.ProductModule, error) {
+	if _, err := s.repo.GetByID(productID); err != nil {
+		return nil, err
+	}
+	m := &models.ProductModule{ProductID: productID}
+	if err := fillModule(m, in); err != nil {
+		return nil, err
+	}
+	if err := s.repo.CreateModule(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (s *ProductService) UpdateModule(productID, id uint, in ProductModuleInput) (*models.ProductModule, error) {
+	m, err := s.repo.GetModule(productID, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := fillModule(m, in); err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateModule(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (s *ProductService) DeleteModule(productID, id uint) error {
+	return s.repo.DeleteModule(productID, id)
+}
+
+func fillModule(m *models.ProductModule, in ProductModuleInput) error {
+	name := strings.Join(strings.Fields(in.Name), " ")
+	if name == "" {
+		return invalidf("nome do módulo é obrigatório")
+	}
+	if models.NormalizeKey(name) == models.LinkModuleGeneral {
+		return invalidf("nome de módulo reservado: %s", name)
+	}
+	pattern := strings.TrimSpace(in.VersionPattern)
+	if pattern != "" {
+		if _, err := compileVersionPattern(pattern); err != nil {
+			return invalidf("versionPattern inválido: %v", err)
+		}
+	}
+	m.Name = name
+	m.Key = models.NormalizeKey(name)
+	m.DisplayName = strings.TrimSpace(in.DisplayName)
+	m.VersionPattern = pattern
+	m.Mandatory = in.Mandatory
+	return nil
+}
+
+func compileVersionPattern(p string) (*regexp.Regexp, error) {
+	return regexp.Compile(`^(?:` + p + `)$`)
+}
+
+// checkModules valida módulos e links de um release contra o cadastro de
+// módulos do produto e normaliza os nomes para a grafia cadastrada.
+// Produtos sem módulos cadastrados não são validados.
+func (s *ReleaseService) checkModules(productID uint, modules []models.ReleaseModule, links []models.FirmwareLink) error {
+	reg, err := s.products.ListModules(productID)
+	if err != nil {
+		return err
+	}
+	if len(reg) == 0 {
+		return nil
+	}
+	byKey := make(map[string]*models.ProductModule, len(reg))
+	for i := range reg {
+		byKey[reg[i].Key] = &reg[i]
+	}
+
+	seen := map[string]bool{}
+	for i := range modules {
+		pm, ok := byKey[models.NormalizeKey(modules[i].Module)]
+		if !ok {
+			return invalidf("módulo desconhecido para o produto: %q", modules[i].Module)
+		}
+		if seen[pm.Key] {
+			return invalidf("módulo repetido: %s", pm.Name)
+		}
+		seen[pm.Key] = true
+		modules[i].Module = pm.Name
+
+		v := strings.TrimSpace(modules[i].Version)
+		if pm.VersionPattern != "" {
+			re, err := compileVersionPattern(pm.VersionPattern)
+			if err != nil {
+				return err
+			}
+			if !re.MatchString(v) {
+				return invalidf("versão %q do módulo %s não segue o padrão %s", v, pm.Name, pm.VersionPattern)
+			}
+		} else if !version.Valid(v) {
+			return invalidf("versão %q do módulo %s inválida", v, pm.Name)
+		}
+	}
+
+	var missing []string
+	for _, pm := range reg {
+		if pm.Mandatory && !seen[pm.Key] {
+			missing = append(missing, pm.Name)
+		}
+	}
+	if len(missing) > 0 {
+		return invalidf("módulos obrigatórios ausentes: %s", strings.Join(missing, ", "))
+	}
+
+	for i := range links {
+		key := models.NormalizeKey(links[i].Module)
+		if key == models.LinkModuleGeneral {
+			continue
+		}
+		pm, ok := byKey[key]
+		if !ok {
+			return invalidf("link %d: módulo desconhecido para o produto: %q", i+1, links[i].Module)
+		}
+		links[i].Module = pm.Name
+	}
+	return nil
+}
@@ -2,61 +2,343 @@ package service
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
 	"time"
 
+	"gorm.io/gorm"
+
 	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
 	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/repository"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/version"
 )
 
 type ReleaseService struct {
-	repo repository.ReleaseRepository
+	repo      repository.ReleaseRepository
+	products  repository.ProductRepository
+	approvals repository.ApprovalRepository
+
+	classifications repository.ClassificationRepository
+	advisories      repository.AdvisoryRepository
+	tags            repository.TagRepository
+
+	// aprovações distintas exigidas para revisao -> producao (0 desliga o fluxo)
+	requiredApprovals int
 }
 
-func NewReleaseService(repo repository.ReleaseRepository) *ReleaseService {
-	return &ReleaseService{repo: repo}
+func NewReleaseService(repo repository.ReleaseRepository, products repository.ProductRepository, approvals repository.ApprovalRepository, classifications repository.ClassificationRepository, advisories repository.AdvisoryRepository, tags repository.TagRepository, requiredApprovals int) *ReleaseService {
+	return &ReleaseService{repo: repo, products: products, approvals: approvals, classifications: classifications, advisories: advisories, tags: tags, requiredApprovals: requiredApprovals}
 }
 
+// Ordenações aceitas em ReleaseQuery.Sort
+const (
+	SortReleaseDate = "release_date" // padrão: data de lançamento, mais recente primeiro
+	SortVersion     = "version"      // versão interpretada, maior primeiro
+)
+
 type ReleaseQuery struct {
-	Q        string
-	Version  string
-	DateFrom *time.Time
-	DateTo   *time.Time
+	Q         string
+	Version   string
+	Product   string // ID ou nome do produto
+	ProductID uint   // já resolvido; tem precedência sobre Product
+	DateFrom  *time.Time
+	DateTo    *time.Time
+	Sort      string
+	Tags      []string // nomes; o release precisa ter todas
+}
+
+// ValidationError indica dado de entrada rejeitado pelas regras de negócio
+// (o handler responde 400 em vez de 500).
+type ValidationError struct{ Msg string }
+
+func (e *ValidationError) Error() string { return e.Msg }
+
+func invalidf(format string, args ...any) error {
+	return &ValidationError{Msg: fmt.Sprintf(format, args...)}
+}
+
+// ErrDuplicateVersion: já existe release com a mesma versão no produto.
+var ErrDuplicateVersion = errors.New("versão já cadastrada para este produto (verifique também a lixeira)")
+
+// ErrStaleRelease: o cliente editou uma versão do release que não é mais a atual.
+var ErrStaleRelease = errors.New("release foi alterado desde que foi carregado; recarregue e reaplique suas mudanças")
+
+func IsValidationError(err error) bool {
+	var ve *ValidationError
+	return errors.As(err, &ve)
+}
+
+// validateVersions exige versão reconhecível e, se houver versão anterior,
+// que ela seja estritamente menor. As duas ficam na forma canônica.
+func validateVersions(rel *models.Release) error {
+	if rel.Version == "" {
+		return invalidf("version é obrigatória")
+	}
+	cur, err := version.Parse(rel.Version)
+	if err != nil {
+		return invalidf("version: %v", err)
+	}
+	// forma canônica: "1.2" e "1.2.0" são a mesma versão para a unicidade
+	rel.Version = cur.String()
+	if rel.PreviousVersion == "" {
+		return nil
+	}
+	prev, err := version.Parse(rel.PreviousVersion)
+	if err != nil {
+		return invalidf("previousVersion: %v", err)
+	}
+	rel.PreviousVersion = prev.String()
+	if prev.Compare(cur) >= 0 {
+		return invalidf("previousVersion (%s) deve ser menor que version (%s)", rel.PreviousVersion, rel.Version)
+	}
+	return nil
+}
+
+// attachProduct amarra o release ao catálogo: pelo ProductID ou, para
+// clientes antigos, pelo ProductName. Os nomes gravados no release são
+// sempre os do catálogo.
+func (s *ReleaseService) attachProduct(rel *models.Release) error {
+	var (
+		p   *models.Product
+		err error
+	)
+	switch {
+	case rel.ProductID != nil && *rel.ProductID != 0:
+		p, err = s.products.GetByID(*rel.ProductID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return invalidf("produto %d não encontrado", *rel.ProductID)
+		}
+	case strings.TrimSpace(rel.ProductName) != "":
+		p, err = s.products.FindByKey(models.NormalizeKey(rel.ProductName))
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return invalidf("produto não cadastrado: %s", rel.ProductName)
+		}
+	default:
+		return invalidf("produto é obrigatório (productId)")
+	}
+	if err != nil {
+		return err
+	}
+
+	rel.ProductID = &p.ID
+	rel.Product = nil
+	rel.ProductName = p.Name
+	rel.ProductCategory = ""
+	if p.Category != nil {
+		rel.ProductCategory = p.Category.Name
+	}
+	return nil
+}
+
+// ResolveProductID devolve o produto que Create usaria para o release
+// (productId ou, no legado, o nome).
+func (s *ReleaseService) ResolveProductID(productID *uint, productName string) (uint, error) {
+	r := models.Release{ProductID: productID, ProductName: productName}
+	if err := s.attachProduct(&r); err != nil {
+		return 0, err
+	}
+	return *r.ProductID, nil
 }
 
-func (s *ReleaseService) Create(in *models.Release) (*models.Release, error) {
-	if in.Version == "" {
-		return nil, errors.New("version é obrigatória")
+func (s *ReleaseService) Create(in *models.Release, role models.Role) (*models.Release, error) {
+	if err := validateVersions(in); err != nil {
+		return nil, err
+	}
+	if err := s.initialStatus(in, role); err != nil {
+		return nil, err
+	}
+	if err := s.attachProduct(in); err != nil {
+		return nil, err
+	}
+	if err := s.checkModules(*in.ProductID, in.Modules, in.Links); err != nil {
+		return nil, err
+	}
+	if err := s.checkClassifications(in.Entries); err != nil {
+		return nil, err
+	}
+	if err := s.checkAdvisories(in.ProductID, in.Entries); err != nil {
+		return nil, err
+	}
+	if err := checkLinkKinds(in.Links); err != nil {
+		return nil, err
+	}
+	if err := checkSignedLinks(nil, in.Links); err != nil {
+		return nil, err
+	}
+	// tags são gravadas com o release (criadas pela Key, sem duplicar)
+	tags, err := normalizeTags(tagNames(in.Tags))
+	if err != nil {
+		return nil, err
 	}
+	in.Tags = tags
 	if err := s.repo.Create(in); err != nil {
+		if IsUniqueViolation(err) {
+			return nil, ErrDuplicateVersion
+		}
 		return nil, err
 	}
-	return s.repo.GetByID(in.ID)
+	out, err := s.repo.GetByID(in.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.recordRevision(out, in.CreatedByUserID, "criação"); err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
 func (s *ReleaseService) Get(id uint) (*models.Release, error) {
 	return s.repo.GetByID(id)
 }
 
+// GetByProductVersion busca pela versão exata e, se não achar, por uma
+// versão equivalente ("1.2" encontra "1.2.0").
+func (s *ReleaseService) GetByProductVersion(productRef, v string) (*models.Release, error) {
+	p, err := resolveProduct(s.products, productRef)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := s.repo.FindByProductVersion(p.ID, v)
+	if err == nil || !errors.Is(err, gorm.ErrRecordNotFound) || !version.Valid(v) {
+		return rel, err
+	}
+	list, err := s.List(ReleaseQuery{ProductID: p.ID})
+	if err != nil {
+		return nil, err
+	}
+	if r := findVersion(list, v); r != nil {
+		return r, nil
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
 func (s *ReleaseService) List(q ReleaseQuery) ([]models.Release, error) {
 	f := repository.ReleaseFilter{
-		Q:        q.Q,
-		Version:  q.Version,
-		DateFrom: q.DateFrom,
-		DateTo:   q.DateTo,
+		Q:         q.Q,
+		Version:   version.Canonical(q.Version), // versões gravadas estão na forma canônica
+		DateFrom:  q.DateFrom,
+		DateTo:    q.DateTo,
+		ProductID: q.ProductID,
 	}
-	return s.repo.List(f)
+	for _, t := range q.Tags {
+		f.Tags = append(f.Tags, models.NormalizeKey(t))
+	}
+	if f.ProductID == 0 && q.Product != "" {
+		p, err := resolveProduct(s.products, q.Product)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return []models.Release{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		f.ProductID = p.ID
+	}
+	list, err := s.repo.List(f)
+	if err != nil {
+		return nil, err
+	}
+	if q.Sort == SortVersion {
+		// a ordem por versão não é expressável em SQL; o repositório já
+		// devolve por data, que serve de desempate
+		// versões não reconhecidas (legado) vão para o fim
+		sort.SliceStable(list, func(i, j int) bool {
+			vi, vj := version.Valid(list[i].Version), version.Valid(list[j].Version)
+			if vi != vj {
+				return vi
+			}
+			return version.Compare(list[i].Version, list[j].Version) > 0
+		})
+	}
+	return list, nil
 }
 
-func (s *ReleaseService) UpdateFull(id uint, base models.Release, modules []models.ReleaseModule, entries []models.ChangelogEntry, links []models.FirmwareLink,) (*models.Release, error) {
+// UpdateFull substitui campos e relações e grava uma nova revisão com o autor.
+// base.LockVersion deve ser a versão lida pelo cliente (ErrStaleRelease se
+// outra edição chegou antes).
+func (s *ReleaseService) UpdateFull(id, userID uint, base models.Release, modules []models.ReleaseModule, entries []models.ChangelogEntry, links []models.FirmwareLink) (*models.Release, error) {
+	return s.updateFull(id, userID, base, modules, entries, links, "edição")
+}
+
+func (s *ReleaseService) updateFull(id, userID uint, base models.Release, modules []models.ReleaseModule, entries []models.ChangelogEntry, links []models.FirmwareLink, note string) (*models.Release, error) {
 	// Atualiza campos simples
 	base.ID = id
-	if err := s.repo.UpdateBaseFields(&base); err != nil {
+	cur, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkEditable(cur); err != nil {
+		return nil, err
+	}
+	if err := s.ensureBaseline(cur); err != nil {
+		return nil, err
+	}
+	// base.LockVersion é a versão que o cliente carregou
+	if base.LockVersion != cur.LockVersion {
+		return nil, ErrStaleRelease
+	}
+	// campos de controle não vêm do cliente
+	base.CreatedByUserID = cur.CreatedByUserID
+	base.CreatedAt = cur.CreatedAt
+	// status só muda por TransitionStatus; omitido = mantém o atual
+	switch base.Status {
+	case "", cur.Status:
+		base.Status = cur.Status
+	default:
+		return nil, invalidf("status não pode ser alterado na edição; use POST /api/releases/%d/status", id)
+	}
+	if err := validateVersions(&base); err != nil {
+		return nil, err
+	}
+	if err := s.attachProduct(&base); err != nil {
+		return nil, err
+	}
+	if err := s.checkModules(*base.ProductID, modules, links); err != nil {
+		return nil, err
+	}
+	if err := s.checkClassifications(entries); err != nil {
+		return nil, err
+	}
+	if err := s.checkAdvisories(base.ProductID, entries); err != nil {
+		return nil, err
+	}
+	if err := checkLinkKinds(links); err != nil {
+		return nil, err
+	}
+	keepFileMetadata(cur.Links, links)
+	if err := checkSignedLinks(cur.Links, links); err != nil {
+		return nil, err
+	}
+	// base.Tags nil = mantém as tags atuais
+	if base.Tags != nil {
+		tags, err := normalizeTags(tagNames(base.Tags))
+		if err != nil {
+			return nil, err
+		}
+		base.Tags = tags
+	}
+	// campos, tags e relações numa só transação
+	out, err := s.repo.UpdateFull(&base, modules, entries, links)
+	if err != nil {
+		if IsUniqueViolation(err) {
+			return nil, ErrDuplicateVersion
+		}
+		if errors.Is(err, repository.ErrLockVersionChanged) {
+			return nil, ErrStaleRelease
+		}
+		return nil, err
+	}
+	// conteúdo mudou: pareceres anteriores não valem mais
+	if err := s.approvals.CancelOpen(id); err != nil {
+		return nil, err
+	}
+	if err := s.recordRevision(out, userID, note); err != nil {
 		return nil, err
 	}
-	// Substitui relações
-	return s.repo.ReplaceRelations(id, modules, entries, links)
+	return out, nil
 }
 
-func (s *ReleaseService) Delete(id uint) error {
-	return s.repo.Delete(id)
+// Delete move o release para a lixeira.
+func (s *ReleaseService) Delete(id, userID uint) error {
+	return s.repo.Delete(id, userID)
 }
@@ -0,0 +1,141 @@
+// internal/service/release_approval.go
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/repository"
+)
+
+var (
+	ErrApprovalRequired = errors.New("release precisa de aprovação completa antes de ir para produção")
+	ErrApprovalPending  = errors.New("já existe pedido de aprovação pendente")
+	ErrNoPendingRequest = errors.New("não há pedido de aprovação pendente")
+	ErrAlreadyDecided   = errors.New("você já deu parecer neste pedido")
+	ErrSelfApproval     = errors.New("quem submeteu o release não pode aprová-lo")
+	ErrNoApproval       = errors.New("release ainda não foi submetido para aprovação")
+	ErrReleasePublished = errors.New("release em producao não pode ser editado sem nova aprovação; volte-o para revisao antes")
+)
+
+// ApprovalsEnabled informa se a publicação exige aprovações.
+func (s *ReleaseService) ApprovalsEnabled() bool { return s.requiredApprovals > 0 }
+
+// SubmitForApproval abre uma rodada de aprovação para um release em revisão.
+func (s *ReleaseService) SubmitForApproval(id, userID uint, comment string) (*models.ReleaseApprovalRequest, error) {
+	if !s.ApprovalsEnabled() {
+		return nil, invalidf("fluxo de aprovação desabilitado")
+	}
+	rel, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if rel.Status != models.FirmwareStatusRevisao {
+		return nil, invalidf("só releases em revisao podem ser submetidos (atual: %s)", rel.Status)
+	}
+	last, err := s.approvals.Latest(id)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if last != nil && last.Status == models.ApprovalPendente {
+		return nil, ErrApprovalPending
+	}
+
+	req := &models.ReleaseApprovalRequest{
+		ReleaseID:         id,
+		SubmittedByUserID: userID,
+		Status:            models.ApprovalPendente,
+		RequiredApprovals: s.requiredApprovals,
+		Comment:           strings.TrimSpace(comment),
+	}
+	if err := s.approvals.CreateRequest(req); err != nil {
+		return nil, err
+	}
+	return s.approvals.Latest(id)
+}
+
+// Approval devolve o pedido de aprovação mais recente.
+func (s *ReleaseService) Approval(id uint) (*models.ReleaseApprovalRequest, error) {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return nil, err
+	}
+	req, err := s.approvals.Latest(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNoApproval
+	}
+	return req, err
+}
+
+// Decide registra aprovação ou rejeição. Uma rejeição encerra o pedido;
+// o pedido é aprovado quando atinge o número exigido de aprovadores
+// distintos.
+func (s *ReleaseService) Decide(id, userID uint, decision models.ApprovalDecision, comment string) (*models.ReleaseApprovalRequest, error) {
+	comment = strings.TrimSpace(comment)
+	if decision == models.DecisionRejeitar && comment == "" {
+		return nil, invalidf("comentário é obrigatório ao rejeitar")
+	}
+
+	req, err := s.approvals.Latest(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrNoPendingRequest
+	}
+	if err != nil {
+		return nil, err
+	}
+	if req.Status != models.ApprovalPendente {
+		return nil, ErrNoPendingRequest
+	}
+	if req.SubmittedByUserID == userID {
+		return nil, ErrSelfApproval
+	}
+
+	for _, d := range req.Decisions {
+		if d.UserID == userID {
+			return nil, ErrAlreadyDecided
+		}
+	}
+
+	// a contagem e o encerramento acontecem com o pedido travado
+	d := &models.ReleaseApproval{RequestID: req.ID, UserID: userID, Decision: decision, Comment: comment}
+	if err := s.approvals.AddDecision(d); err != nil {
+		if IsUniqueViolation(err) {
+			return nil, ErrAlreadyDecided
+		}
+		if errors.Is(err, repository.ErrRequestClosed) {
+			return nil, ErrNoPendingRequest
+		}
+		return nil, err
+	}
+	return s.approvals.Latest(id)
+}
+
+// checkEditable barra mudanças de conteúdo em releases já aprovados e
+// publicados: com o fluxo de aprovação ligado, a edição passa por revisao
+// e por uma nova rodada de aprovação.
+func (s *ReleaseService) checkEditable(r *models.Release) error {
+	if s.ApprovalsEnabled() && r.Status == models.FirmwareStatusProducao {
+		return ErrReleasePublished
+	}
+	return nil
+}
+
+// checkApproved é chamado em toda transição para producao.
+func (s *ReleaseService) checkApproved(id uint) error {
+	if !s.ApprovalsEnabled() {
+		return nil
+	}
+	req, err := s.approvals.Latest(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrApprovalRequired
+	}
+	if err != nil {
+		return err
+	}
+	if req.Status != models.ApprovalAprovado {
+		return ErrApprovalRequired
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+// internal/service/release_links.go
+package service
+
+import (
+	"strings"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+)
+
+// checkLinkKinds valida o tipo de cada link; sem tipo informado, ele é
+// deduzido do nome do arquivo.
+func checkLinkKinds(links []models.FirmwareLink) error {
+	for i := range links {
+		k := models.ParseLinkKind(string(links[i].Kind))
+		if k == "" {
+			k = models.GuessLinkKind(links[i].URL, links[i].Description)
+		}
+		if !k.Valid() {
+			return invalidf("link %d: kind inválido %q (use firmware|bootloader|checksum|release-notes-pdf|sbom|other)", i+1, links[i].Kind)
+		}
+		links[i].Kind = k
+		links[i].HardwareRevision = strings.TrimSpace(links[i].HardwareRevision)
+	}
+	return nil
+}
+
+// checkSignedLinks exige assinatura verificada em links de firmware e
+// bootloader. SignatureKeyID só é preenchido pelo upload assinado (o
+// cliente não o informa), então esses links só entram por ele. O tipo
+// declarado não basta: um .bin declarado como "other" também é firmware
+// pelo nome do arquivo (GuessLinkKind). Links gravados antes da exigência
+// (before) continuam valendo enquanto não mudarem de URL nem de tipo.
+func checkSignedLinks(before, links []models.FirmwareLink) error {
+	for i, l := range links {
+		if !needsSignature(l) || l.SignatureKeyID != "" {
+			continue
+		}
+		if j := findLink(before, l.ID); l.ID != 0 && j >= 0 && before[j].URL == l.URL && before[j].Kind == l.Kind {
+			continue
+		}
+		kind := l.Kind
+		if !isFirmwareKind(kind) {
+			kind = models.GuessLinkKind(l.URL, l.Description)
+		}
+		return invalidf(`link %d: %s sem assinatura verificada; envie o arquivo com "signature" ou "manifest" no upload (na criação ou em POST /api/releases/:id/links)`, i+1, kind)
+	}
+	return nil
+}
+
+func isFirmwareKind(k models.LinkKind) bool {
+	return k == models.LinkKindFirmware || k == models.LinkKindBootloader
+}
+
+// needsSignature: o tipo declarado ou o deduzido da URL é firmware ou
+// bootloader.
+func needsSignature(l models.FirmwareLink) bool {
+	return isFirmwareKind(l.Kind) || isFirmwareKind(models.GuessLinkKind(l.URL, l.Description))
+}
+
+// keepFileMetadata copia os metadados do arquivo enviado (SHA-256,
+// tamanho, MIME, nome original, chave da assinatura) para os links editados
+// que mantêm o ID e a URL de um link atual. O cliente não informa esses
+// campos; trocar a URL os descarta.
+func keepFileMetadata(cur, links []models.FirmwareLink) {
+	for i := range links {
+		if links[i].ID == 0 || links[i].SHA256 != "" {
+			continue
+		}
+		j := findLink(cur, links[i].ID)
+		if j < 0 || cur[j].URL != links[i].URL {
+			continue
+		}
+		links[i].SHA256, links[i].SizeBytes = cur[j].SHA256, cur[j].SizeBytes
+		links[i].MIMEType, links[i].OriginalFilename = cur[j].MIMEType, cur[j].OriginalFilename
+		links[i].SignatureKeyID = cur[j].SignatureKeyID
+	}
+}
+
+// MatchLinks filtra links por tipo e revisão de hardware (filtros vazios
+// não restringem). Links sem revisão servem para qualquer revisão.
+func MatchLinks(links []models.FirmwareLink, kind models.LinkKind, hardwareRevision string) []models.FirmwareLink {
+	rev := models.NormalizeKey(hardwareRevision)
+	out := make([]models.FirmwareLink, 0, len(links))
+	for _, l := range links {
+		if kind != "" && l.Kind != kind {
+			continue
+		}
+		if rev != "" && l.HardwareRevision != "" && models.NormalizeKey(l.HardwareRevision) != rev {
+			continue
+		}
+		out = append(out, l)
+	}
+	return out
+}
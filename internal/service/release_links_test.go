@@ -0,0 +1,71 @@
+package service_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/service"
+)
+
+func TestMatchLinks(t *testing.T) {
+	links := []models.FirmwareLink{
+		{ID: 1, Kind: models.LinkKindFirmware, HardwareRevision: "Rev B"},
+		{ID: 2, Kind: models.LinkKindFirmware, HardwareRevision: "C"},
+		{ID: 3, Kind: models.LinkKindFirmware},
+		{ID: 4, Kind: models.LinkKindChecksum, HardwareRevision: "rev b"},
+		{ID: 5, Kind: models.LinkKindReleaseNotesPDF},
+	}
+	for _, tc := range []struct {
+		name string
+		kind models.LinkKind
+		rev  string
+		want []uint
+	}{
+		{"sem filtro", "", "", []uint{1, 2, 3, 4, 5}},
+		{"só kind", models.LinkKindFirmware, "", []uint{1, 2, 3}},
+		{"revisão vale sem caixa e espaços", "", "REV B", []uint{1, 3, 4, 5}},
+		{"kind e revisão", models.LinkKindFirmware, "c", []uint{2, 3}},
+		{"revisão sem links próprios", models.LinkKindFirmware, "D", []uint{3}},
+		{"kind sem links", models.LinkKindSBOM, "", []uint{}},
+	} {
+		got := []uint{}
+		for _, l := range service.MatchLinks(links, tc.kind, tc.rev) {
+			got = append(got, l.ID)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: got %v, esperava %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestCheckSignedLinks(t *testing.T) {
+	const bin = "https://files.example.com/firmware/app.bin"
+	legacy := []models.FirmwareLink{
+		{ID: 1, Kind: models.LinkKindFirmware, URL: bin},
+		{ID: 2, Kind: models.LinkKindOther, URL: bin},
+	}
+	for _, tc := range []struct {
+		name    string
+		before  []models.FirmwareLink
+		link    models.FirmwareLink
+		wantErr bool
+	}{
+		{"firmware assinado", nil, models.FirmwareLink{Kind: models.LinkKindFirmware, URL: bin, SignatureKeyID: "0011223344556677"}, false},
+		{"firmware novo sem assinatura", nil, models.FirmwareLink{Kind: models.LinkKindFirmware, URL: bin}, true},
+		{"bootloader novo sem assinatura", nil, models.FirmwareLink{Kind: models.LinkKindBootloader, URL: bin}, true},
+		{"outros tipos não precisam", nil, models.FirmwareLink{Kind: models.LinkKindReleaseNotesPDF, URL: "https://files.example.com/docs/notas.pdf"}, false},
+		{".bin declarado como other", nil, models.FirmwareLink{Kind: models.LinkKindOther, URL: bin}, true},
+		{".bin declarado como pdf", nil, models.FirmwareLink{Kind: models.LinkKindReleaseNotesPDF, URL: bin}, true},
+		{"legado sem mudança", legacy, models.FirmwareLink{ID: 1, Kind: models.LinkKindFirmware, URL: bin}, false},
+		{"legado other sem mudança", legacy, models.FirmwareLink{ID: 2, Kind: models.LinkKindOther, URL: bin}, false},
+		{"legado com outra URL", legacy, models.FirmwareLink{ID: 1, Kind: models.LinkKindFirmware, URL: bin + "?v=2"}, true},
+		{"legado virando firmware", legacy, models.FirmwareLink{ID: 2, Kind: models.LinkKindFirmware, URL: bin}, true},
+		{"ID de outro release", legacy, models.FirmwareLink{ID: 9, Kind: models.LinkKindFirmware, URL: bin}, true},
+	} {
+		err := service.CheckSignedLinks(tc.before, []models.FirmwareLink{tc.link})
+		if (err != nil) != tc.wantErr {
+			t.Errorf("%s: err = %v", tc.name, err)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+// internal/service/release_relations.go
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/repository"
+)
+
+var (
+	ErrEntryNotFound  = errors.New("entrada não encontrada neste release")
+	ErrModuleNotFound = errors.New("módulo não encontrado neste release")
+	ErrLinkNotFound   = errors.New("link não encontrado neste release")
+)
+
+// tentativas de editRelations quando outra alteração chega no meio
+const relationEditAttempts = 3
+
+// editRelations aplica edit sobre o estado atual do release e grava as
+// relações resultantes como uma nova revisão. Sem ifMatch, uma alteração
+// concorrente faz a edição ser refeita sobre o estado novo; com ifMatch, a
+// versão lida pelo cliente precisa ser a atual (ErrStaleRelease).
+func (s *ReleaseService) editRelations(id, userID uint, ifMatch *int, edit func(r *models.Release) (string, error)) (*models.Release, error) {
+	for attempt := 1; ; attempt++ {
+		cur, err := s.repo.GetByID(id)
+		if err != nil {
+			return nil, err
+		}
+		if ifMatch != nil && *ifMatch != cur.LockVersion {
+			return nil, ErrStaleRelease
+		}
+		if err := s.checkEditable(cur); err != nil {
+			return nil, err
+		}
+		if err := s.ensureBaseline(cur); err != nil {
+			return nil, err
+		}
+		before := append([]models.FirmwareLink(nil), cur.Links...)
+		note, err := edit(cur)
+		if err != nil {
+			return nil, err
+		}
+		if cur.ProductID != nil {
+			if err := s.checkModules(*cur.ProductID, cur.Modules, cur.Links); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.checkClassifications(cur.Entries); err != nil {
+			return nil, err
+		}
+		if err := s.checkAdvisories(cur.ProductID, cur.Entries); err != nil {
+			return nil, err
+		}
+		if err := checkLinkKinds(cur.Links); err != nil {
+			return nil, err
+		}
+		if err := checkSignedLinks(before, cur.Links); err != nil {
+			return nil, err
+		}
+
+		out, err := s.repo.UpdateRelations(id, cur.LockVersion, cur.Modules, cur.Entries, cur.Links)
+		if errors.Is(err, repository.ErrLockVersionChanged) {
+			if ifMatch == nil && attempt < relationEditAttempts {
+				continue
+			}
+			return nil, ErrStaleRelease
+		}
+		if err != nil {
+			return nil, err
+		}
+		// conteúdo mudou: pareceres anteriores não valem mais
+		if err := s.approvals.CancelOpen(id); err != nil {
+			return nil, err
+		}
+		if err := s.recordRevision(out, userID, note); err != nil {
+			return nil, err
+		}
+		return out, nil
+	}
+}
+
+/* ===== Entradas do changelog ===== */
+
+func validateEntry(e *models.ChangelogEntry) error {
+	e.Observation = strings.TrimSpace(e.Observation)
+	if e.Observation == "" {
+		return invalidf("observação da entrada é obrigatória")
+	}
+	if strings.TrimSpace(string(e.Classification)) == "" {
+		return invalidf("classificação da entrada é obrigatória")
+	}
+	return nil
+}
+
+// placeEntry move a entrada idx para a posição pos (1..n; fora da faixa
+// vai para o fim) e renumera ItemOrder.
+func placeEntry(entries []models.ChangelogEntry, idx, pos int) []models.ChangelogEntry {
+	e := entries[idx]
+	rest := append(append([]models.ChangelogEntry{}, entries[:idx]...), entries[idx+1:]...)
+	if pos < 1 || pos > len(rest)+1 {
+		pos = len(rest) + 1
+	}
+	out := append(append(append([]models.ChangelogEntry{}, rest[:pos-1]...), e), rest[pos-1:]...)
+	renumberEntries(out)
+	return out
+}
+
+func renumberEntries(entries []models.ChangelogEntry) {
+	for i := range entries {
+		entries[i].ItemOrder = i + 1
+	}
+}
+
+func findEntry(entries []models.ChangelogEntry, entryID uint) int {
+	for i := range entries {
+		if entries[i].ID == entryID {
+			return i
+		}
+	}
+	return -1
+}
+
+// AddEntry inclui uma entrada na posição e.ItemOrder (0 = no fim) e
+// devolve o ID dela.
+func (s *ReleaseService) AddEntry(id, userID uint, ifMatch *int, e models.ChangelogEntry) (*models.Release, uint, error) {
+	if err := validateEntry(&e); err != nil {
+		return nil, 0, err
+	}
+	var added *models.ChangelogEntry
+	out, err := s.editRelations(id, userID, ifMatch, func(r *models.Release) (string, error) {
+		e.ID = 0
+		r.Entries = placeEntry(append(r.Entries, e), len(r.Entries), e.ItemOrder)
+		// a gravação preenche o ID no próprio slice
+		added = &r.Entries[findEntry(r.Entries, 0)]
+		return "entrada adicionada", nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return out, added.ID, nil
+}
+
+// UpdateEntry troca classificação, texto e boletim; e.ItemOrder > 0 também move a entrada.
+func (s *ReleaseService) UpdateEntry(id, entryID, userID uint, ifMatch *int, e models.ChangelogEntry) (*models.Release, error) {
+	if err := validateEntry(&e); err != nil {
+		return nil, err
+	}
+	return s.editRelations(id, userID, ifMatch, func(r *models.Release) (string, error) {
+		i := findEntry(r.Entries, entryID)
+		if i < 0 {
+			return "", ErrEntryNotFound
+		}
+		r.Entries[i].Classification = e.Classification
+		r.Entries[i].Observation = e.Observation
+		r.Entries[i].AdvisoryID = e.AdvisoryID
+		if e.ItemOrder > 0 {
+			r.Entries = placeEntry(r.Entries, i, e.ItemOrder)
+		}
+		return fmt.Sprintf("entrada %d alterada", entryID), nil
+	})
+}
+
+func (s *ReleaseService) DeleteEntry(id, entryID, userID uint, ifMatch *int) (*models.Release, error) {
+	return s.editRelations(id, userID, ifMatch, func(r *models.Release) (string, error) {
+		i := findEntry(r.Entries, entryID)
+		if i < 0 {
+			return "", ErrEntryNotFound
+		}
+		r.Entries = append(r.Entries[:i], r.Entries[i+1:]...)
+		renumberEntries(r.Entries)
+		return fmt.Sprintf("entrada %d removida", entryID), nil
+	})
+}
+
+// ReorderEntries recebe todos os IDs de entrada do release na nova ordem.
+func (s *ReleaseService) ReorderEntries(id, userID uint, ifMatch *int, order []uint) (*models.Release, error) {
+	return s.editRelations(id, userID, ifMatch, func(r *models.Release) (string, error) {
+		if len(order) != len(r.Entries) {
+			return "", invalidf("a nova ordem deve listar as %d entradas do release", len(r.Entries))
+		}
+		pos := make(map[uint]int, len(order))
+		for i, eid := range order {
+			if _, dup := pos[eid]; dup {
+				return "", invalidf("entrada %d repetida na nova ordem", eid)
+			}
+			pos[eid] = i
+		}
+		for _, e := range r.Entries {
+			if _, ok := pos[e.ID]; !ok {
+				return "", invalidf("entrada %d ausente da nova ordem", e.ID)
+			}
+		}
+		sort.SliceStable(r.Entries, func(a, b int) bool { return pos[r.Entries[a].ID] < pos[r.Entries[b].ID] })
+		renumberEntries(r.Entries)
+		return "entradas reordenadas", nil
+	})
+}
+
+/* ===== Módulos ===== */
+
+func findModule(modules []models.ReleaseModule, moduleID uint) int {
+	for i := range modules {
+		if modules[i].ID == moduleID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *ReleaseService) AddModule(id, userID uint, ifMatch *int, m models.ReleaseModule) (*models.Release, uint, error) {
+	var added *models.ReleaseModule
+	out, err := s.editRelations(id, userID, ifMatch, func(r *models.Release) (string, error) {
+		m.ID = 0
+		r.Modules = append(r.Modules, m)
+		added = &r.Modules[len(r.Modules)-1]
+		return fmt.Sprintf("módulo %s adicionado", m.Module), nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return out, added.ID, nil
+}
+
+func (s *ReleaseService) UpdateModule(id, moduleID, userID uint, ifMatch *int, m models.ReleaseModule) (*models.Release, error) {
+	return s.editRelations(id, userID, ifMatch, func(r *models.Release) (string, error) {
+		i := findModule(r.Modules, moduleID)
+		if i < 0 {
+			return "", ErrModuleNotFound
+		}
+		r.Modules[i].Module = m.Module
+		r.Modules[i].Version = m.Version
+		r.Modules[i].Updated = m.Updated
+		return fmt.Sprintf("módulo %s alterado", m.Module), nil
+	})
+}
+
+func (s *ReleaseService) DeleteModule(id, moduleID, userID uint, ifMatch *int) (*models.Release, error) {
+	return s.editRelations(id, userID, ifMatch, func(r *models.Release) (string, error) {
+		i := findModule(r.Modules, moduleID)
+		if i < 0 {
+			return "", ErrModuleNotFound
+		}
+		name := r.Modules[i].Module
+		r.Modules = append(r.Modules[:i], r.Modules[i+1:]...)
+		return fmt.Sprintf("módulo %s removido", name), nil
+	})
+}
+
+/* ===== Links ===== */
+
+func findLink(links []models.FirmwareLink, linkID uint) int {
+	for i := range links {
+		if links[i].ID == linkID {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *ReleaseService) AddLink(id, userID uint, ifMatch *int, l models.FirmwareLink) (*models.Release, uint, error) {
+	var added *models.FirmwareLink
+	out, err := s.editRelations(id, userID, ifMatch, func(r *models.Release) (string, error) {
+		l.ID = 0
+		r.Links = append(r.Links, l)
+		added = &r.Links[len(r.Links)-1]
+		return "link adicionado", nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	return out, added.ID, nil
+}
+
+func (s *ReleaseService) UpdateLink(id, linkID, userID uint, ifMatch *int, l models.FirmwareLink) (*models.Release, error) {
+	return s.editRelations(id, userID, ifMatch, func(r *models.Release) (string, error) {
+		i := findLink(r.Links, linkID)
+		if i < 0 {
+			return "", ErrLinkNotFound
+		}
+		if r.Links[i].URL != l.URL {
+			// outro arquivo: os metadados do envio anterior não valem mais
+			r.Links[i].SHA256, r.Links[i].SizeBytes = "", 0
+			r.Links[i].MIMEType, r.Links[i].OriginalFilename = "", ""
+			r.Links[i].SignatureKeyID = ""
+		}
+		r.Links[i].Module = l.Module
+		r.Links[i].Description = l.Description
+		r.Links[i].URL = l.URL
+		r.Links[i].Kind = l.Kind
+		r.Links[i].HardwareRevision = l.HardwareRevision
+		return fmt.Sprintf("link %d alterado", linkID), nil
+	})
+}
+
+func (s *ReleaseService) DeleteLink(id, linkID, userID uint, ifMatch *int) (*models.Release, error) {
+	return s.editRelations(id, userID, ifMatch, func(r *models.Release) (string, error) {
+		i := findLink(r.Links, linkID)
+		if i < 0 {
+			return "", ErrLinkNotFound
+		}
+		r.Links = append(r.Links[:i], r.Links[i+1:]...)
+		return fmt.Sprintf("link %d removido", linkID), nil
+	})
+}
@@ -0,0 +1,73 @@
+// internal/service/release_revision.go
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+)
+
+// recordRevision grava o estado atual do release como nova revisão.
+func (s *ReleaseService) recordRevision(rel *models.Release, authorID uint, note string) error {
+	raw, err := json.Marshal(models.NewReleaseSnapshot(rel))
+	if err != nil {
+		return err
+	}
+	return s.repo.AddRevision(&models.ReleaseRevision{
+		ReleaseID:    rel.ID,
+		Snapshot:     string(raw),
+		Note:         note,
+		AuthorUserID: authorID,
+	})
+}
+
+// ensureBaseline cria a revisão 1 para releases anteriores ao histórico,
+// para que a primeira edição não perca o conteúdo original.
+func (s *ReleaseService) ensureBaseline(cur *models.Release) error {
+	n, err := s.repo.CountRevisions(cur.ID)
+	if err != nil || n > 0 {
+		return err
+	}
+	return s.recordRevision(cur, cur.CreatedByUserID, "estado anterior ao histórico")
+}
+
+func (s *ReleaseService) ListRevisions(id uint) ([]models.ReleaseRevision, error) {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return nil, err
+	}
+	return s.repo.ListRevisions(id)
+}
+
+// Revision devolve a revisão e o release remontado a partir dela.
+func (s *ReleaseService) Revision(id uint, rev int) (*models.ReleaseRevision, *models.Release, error) {
+	rv, err := s.repo.GetRevision(id, rev)
+	if err != nil {
+		return nil, nil, err
+	}
+	snap, err := rv.Decode()
+	if err != nil {
+		return nil, nil, fmt.Errorf("revisão %d ilegível: %w", rev, err)
+	}
+	rel := snap.Release(id)
+	return rv, &rel, nil
+}
+
+// RestoreRevision aplica o conteúdo de uma revisão como uma nova edição.
+// O status não é restaurado: ele segue a máquina de estados.
+func (s *ReleaseService) RestoreRevision(id uint, rev int, userID uint) (*models.Release, error) {
+	_, old, err := s.Revision(id, rev)
+	if err != nil {
+		return nil, err
+	}
+	cur, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	base := *old
+	base.Status = ""
+	base.LockVersion = cur.LockVersion
+	base.Modules, base.Entries, base.Links = nil, nil, nil
+	// linhas que ainda existem mantêm o ID; as removidas depois voltam com ID novo
+	return s.updateFull(id, userID, base, old.Modules, old.Entries, old.Links, fmt.Sprintf("restaurada da revisão %d", rev))
+}
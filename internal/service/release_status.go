@@ -0,0 +1,99 @@
+// internal/service/release_status.go
+package service
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/repository"
+)
+
+var (
+	ErrInvalidTransition   = errors.New("transição de status não permitida")
+	ErrTransitionForbidden = errors.New("seu papel não permite esta transição de status")
+)
+
+// initialStatus valida o status de criação e registra a transição inicial.
+// Com o fluxo de aprovação ligado, releases novos nascem em revisao.
+func (s *ReleaseService) initialStatus(rel *models.Release, role models.Role) error {
+	if rel.Status == "" {
+		rel.Status = models.FirmwareStatusProducao
+		if s.ApprovalsEnabled() {
+			rel.Status = models.FirmwareStatusRevisao
+		}
+	}
+	if !rel.Status.Valid() {
+		return invalidf("status inválido: use revisao|producao|descontinuado")
+	}
+	if rel.Status == models.FirmwareStatusProducao && s.ApprovalsEnabled() {
+		return ErrApprovalRequired
+	}
+	if !models.FirmwareStatus("").TransitionAllowed(rel.Status, role) {
+		return ErrTransitionForbidden
+	}
+	rel.StatusTransitions = []models.ReleaseStatusTransition{{
+		ToStatus:        rel.Status,
+		Reason:          "criação do release",
+		ChangedByUserID: rel.CreatedByUserID,
+	}}
+	return nil
+}
+
+// TransitionStatus muda o status seguindo a máquina de estados de
+// models.FirmwareStatus. O motivo é obrigatório e fica no histórico.
+func (s *ReleaseService) TransitionStatus(id uint, to models.FirmwareStatus, reason string, userID uint, role models.Role) (*models.Release, error) {
+	if !to.Valid() {
+		return nil, invalidf("status inválido: use revisao|producao|descontinuado")
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, invalidf("motivo (reason) é obrigatório")
+	}
+
+	cur, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if !cur.Status.CanTransition(to) {
+		return nil, ErrInvalidTransition
+	}
+	if !cur.Status.TransitionAllowed(to, role) {
+		return nil, ErrTransitionForbidden
+	}
+	// qualquer caminho para produção (inclusive via descontinuado) passa
+	// pela aprovação
+	if to == models.FirmwareStatusProducao {
+		if err := s.checkApproved(id); err != nil {
+			return nil, err
+		}
+	}
+
+	t := &models.ReleaseStatusTransition{
+		ReleaseID:       id,
+		FromStatus:      cur.Status,
+		ToStatus:        to,
+		Reason:          reason,
+		ChangedByUserID: userID,
+	}
+	if err := s.repo.UpdateStatus(t); err != nil {
+		if errors.Is(err, repository.ErrStatusChanged) {
+			return nil, ErrInvalidTransition
+		}
+		return nil, err
+	}
+	// voltar para revisão exige nova rodada de aprovação
+	if to == models.FirmwareStatusRevisao {
+		if err := s.approvals.CancelOpen(id); err != nil {
+			return nil, err
+		}
+	}
+	return s.repo.GetByID(id)
+}
+
+func (s *ReleaseService) StatusHistory(id uint) ([]models.ReleaseStatusTransition, error) {
+	if _, err := s.repo.GetByID(id); err != nil {
+		return nil, err
+	}
+	return s.repo.ListStatusTransitions(id)
+}
@@ -0,0 +1,97 @@
+// internal/service/release_tags.go
+package service
+
+import (
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"gorm.io/gorm"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/repository"
+)
+
+const (
+	maxTagsPerRelease = 20
+	maxTagLength      = 60
+)
+
+// normalizeTags limpa os nomes e descarta repetidos (mesma Key); o
+// primeiro nome informado é o que fica.
+func normalizeTags(names []string) ([]models.Tag, error) {
+	out := make([]models.Tag, 0, len(names))
+	seen := map[string]bool{}
+	for _, n := range names {
+		name := strings.Join(strings.Fields(n), " ")
+		if name == "" {
+			return nil, invalidf("tag vazia")
+		}
+		if utf8.RuneCountInString(name) > maxTagLength {
+			return nil, invalidf("tag %q passa de %d caracteres", name, maxTagLength)
+		}
+		key := models.NormalizeKey(name)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		out = append(out, models.Tag{Name: name, Key: key})
+	}
+	if len(out) > maxTagsPerRelease {
+		return nil, invalidf("no máximo %d tags por release", maxTagsPerRelease)
+	}
+	return out, nil
+}
+
+func tagNames(tags []models.Tag) []string {
+	out := make([]string, 0, len(tags))
+	for _, t := range tags {
+		out = append(out, t.Name)
+	}
+	return out
+}
+
+// SetTags troca as tags do release. Tags não mudam o lock_version (são
+// rótulos, não conteúdo), mas a troca entra no histórico de revisões para
+// que uma restauração as traga de volta.
+func (s *ReleaseService) SetTags(id, userID uint, names []string) (*models.Release, error) {
+	cur, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := normalizeTags(names)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureBaseline(cur); err != nil {
+		return nil, err
+	}
+	if err := s.tags.Replace(id, tags); err != nil {
+		return nil, err
+	}
+	out, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.recordRevision(out, userID, "tags alteradas"); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ListTags conta o uso de cada tag, opcionalmente só no produto informado
+// (ID ou nome).
+func (s *ReleaseService) ListTags(productRef string) ([]repository.TagCount, error) {
+	var productID uint
+	if productRef != "" {
+		p, err := resolveProduct(s.products, productRef)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return []repository.TagCount{}, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		productID = p.ID
+	}
+	return s.tags.List(productID)
+}
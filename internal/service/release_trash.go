@@ -0,0 +1,67 @@
+// internal/service/release_trash.go
+package service
+
+import (
+	"log"
+	"time"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+)
+
+func (s *ReleaseService) ListTrash() ([]models.Release, error) {
+	return s.repo.ListTrashed()
+}
+
+func (s *ReleaseService) RestoreFromTrash(id, userID uint) (*models.Release, error) {
+	if err := s.repo.Restore(id); err != nil {
+		return nil, err
+	}
+	out, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.recordRevision(out, userID, "restaurado da lixeira"); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PurgeExpired apaga de vez os releases na lixeira há mais de retention.
+// deleteFile é chamado para cada link cuja URL nenhum outro release usa
+// (o arquivo pode ser compartilhado); se algum falhar, o release fica para
+// a próxima rodada (evita arquivo órfão no servidor).
+func (s *ReleaseService) PurgeExpired(retention time.Duration, deleteFile func(url string) error) (int, error) {
+	list, err := s.repo.ListTrashedBefore(time.Now().Add(-retention))
+	if err != nil {
+		return 0, err
+	}
+	purged := 0
+	for _, rel := range list {
+		ok := true
+		for _, lk := range rel.Links {
+			shared, err := s.repo.URLInUseElsewhere(lk.URL, rel.ID)
+			if err != nil {
+				log.Printf("lixeira: release %d: falha ao checar %s: %v", rel.ID, lk.URL, err)
+				ok = false
+				continue
+			}
+			if shared {
+				log.Printf("lixeira: release %d: %s mantido (usado por outro release)", rel.ID, lk.URL)
+				continue
+			}
+			if err := deleteFile(lk.URL); err != nil {
+				log.Printf("lixeira: release %d: falha ao apagar %s: %v", rel.ID, lk.URL, err)
+				ok = false
+			}
+		}
+		if !ok {
+			continue
+		}
+		if err := s.repo.Purge(rel.ID); err != nil {
+			log.Printf("lixeira: release %d: falha na purga: %v", rel.ID, err)
+			continue
+		}
+		purged++
+	}
+	return purged, nil
+}
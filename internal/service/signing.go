@@ -0,0 +1,137 @@
+// internal/service/signing.go
+package service
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/repository"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/signing"
+)
+
+type SigningService struct {
+	repo     repository.SigningKeyRepository
+	products repository.ProductRepository
+}
+
+func NewSigningService(repo repository.SigningKeyRepository, products repository.ProductRepository) *SigningService {
+	return &SigningService{repo: repo, products: products}
+}
+
+type SigningKeyInput struct {
+	Name      string
+	PublicKey string // PEM
+}
+
+// SignedUpload é o arquivo enviado com a assinatura destacada ou com o
+// manifesto assinado (um dos dois).
+type SignedUpload struct {
+	Filename  string
+	SHA256    []byte // digests do arquivo
+	SHA512    []byte
+	Content   []byte // o arquivo inteiro, se coube em memória; nil se não
+	Size      int64
+	Signature []byte // bytes ou base64
+	Manifest  []byte // envelope JSON (ver signing.OpenManifest)
+}
+
+func (s *SigningService) List(productRef string) ([]models.SigningKey, error) {
+	p, err := resolveProduct(s.products, productRef)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.ListByProduct(p.ID)
+}
+
+func (s *SigningService) Create(productRef string, in SigningKeyInput) (*models.SigningKey, error) {
+	p, err := resolveProduct(s.products, productRef)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSpace(in.Name)
+	if name == "" {
+		return nil, invalidf("nome da chave é obrigatório")
+	}
+	pub, err := signing.ParsePublicKey(in.PublicKey)
+	if err != nil {
+		return nil, invalidf("%v", err)
+	}
+	k := &models.SigningKey{
+		ProductID: p.ID, Name: name,
+		KeyID: pub.ID, Algorithm: string(pub.Algorithm), PublicKey: pub.PEM(),
+	}
+	if err := s.repo.Create(k); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Delete remove a chave do produto; links já assinados guardam o KeyID.
+func (s *SigningService) Delete(productRef string, id uint) error {
+	p, err := resolveProduct(s.products, productRef)
+	if err != nil {
+		return err
+	}
+	k, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if k.ProductID != p.ID {
+		return gorm.ErrRecordNotFound
+	}
+	return s.repo.Delete(id)
+}
+
+// VerifyUpload confere a assinatura do arquivo contra as chaves do produto
+// e devolve a chave que a validou.
+func (s *SigningService) VerifyUpload(productID uint, up SignedUpload) (*models.SigningKey, error) {
+	keys, err := s.repo.ListByProduct(productID)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		return nil, invalidf("produto sem chave de assinatura cadastrada; o firmware não pode ser publicado")
+	}
+
+	var verify func(pub *signing.PublicKey) bool
+	switch {
+	case len(up.Manifest) > 0:
+		m, payload, sig, err := signing.OpenManifest(up.Manifest)
+		if err != nil {
+			return nil, invalidf("%v", err)
+		}
+		if m.SHA256 != hex.EncodeToString(up.SHA256) {
+			return nil, invalidf("o SHA-256 do manifesto não confere com o arquivo enviado")
+		}
+		if m.Filename != "" && m.Filename != up.Filename {
+			return nil, invalidf("o manifesto é do arquivo %q, não de %q", m.Filename, up.Filename)
+		}
+		if m.Size != 0 && m.Size != up.Size {
+			return nil, invalidf("o tamanho no manifesto (%d) não confere com o arquivo (%d)", m.Size, up.Size)
+		}
+		verify = func(pub *signing.PublicKey) bool { return pub.Verify(payload, sig) }
+	case len(signing.DecodeSignature(up.Signature)) > 0:
+		sig := signing.DecodeSignature(up.Signature)
+		d := signing.FileDigest{SHA256: up.SHA256, SHA512: up.SHA512, Content: up.Content}
+		verify = func(pub *signing.PublicKey) bool { return pub.VerifyFile(d, sig) }
+	default:
+		return nil, invalidf(`firmware sem assinatura: envie a parte "signature" (assinatura destacada) ou "manifest" (manifesto assinado)`)
+	}
+
+	for i := range keys {
+		pub, err := signing.ParsePublicKey(keys[i].PublicKey)
+		if err != nil {
+			continue
+		}
+		if verify(pub) {
+			return &keys[i], nil
+		}
+	}
+	if len(up.Manifest) == 0 && up.Content == nil {
+		return nil, invalidf("assinatura inválida: não confere com nenhuma chave cadastrada do produto (arquivo grande demais para Ed25519 puro: use Ed25519ph ou manifesto)")
+	}
+	return nil, invalidf("assinatura inválida: não confere com nenhuma chave cadastrada do produto")
+}
@@ -0,0 +1,269 @@
+// internal/service/translation.go
+package service
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	"gorm.io/gorm"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/i18n"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/repository"
+)
+
+var ErrTranslationNotFound = errors.New("tradução não encontrada para este idioma")
+
+// TranslationService cuida das traduções de ImportantNote, OTAObs e das
+// observações das entradas. O texto gravado no release é o do idioma de
+// origem (langs.Source) e serve de fallback.
+type TranslationService struct {
+	releases repository.ReleaseRepository
+	repo     repository.TranslationRepository
+	langs    i18n.Languages
+}
+
+func NewTranslationService(releases repository.ReleaseRepository, repo repository.TranslationRepository, langs i18n.Languages) *TranslationService {
+	return &TranslationService{releases: releases, repo: repo, langs: langs}
+}
+
+func (s *TranslationService) Languages() i18n.Languages { return s.langs }
+
+type EntryTranslation struct {
+	EntryID     uint
+	Observation string
+	source      string // hash do original traduzido
+}
+
+// TranslationGaps lista os textos (não vazios no original) sem tradução ou
+// com tradução desatualizada (o original mudou depois de traduzido).
+type TranslationGaps struct {
+	ImportantNote bool
+	OTAObs        bool
+	EntryIDs      []uint
+}
+
+func (g TranslationGaps) Complete() bool {
+	return !g.ImportantNote && !g.OTAObs && len(g.EntryIDs) == 0
+}
+
+type LanguageTranslation struct {
+	Lang          string
+	ImportantNote string
+	OTAObs        string
+	Entries       []EntryTranslation
+	Missing       TranslationGaps
+
+	importantNoteSource, otaObsSource string
+}
+
+// Localize troca, em cada release, os textos pelos do idioma lang. Textos
+// sem tradução (ou com tradução desatualizada) ficam no original; o mapa
+// devolvido marca esses releases.
+func (s *TranslationService) Localize(list []*models.Release, lang string) (map[uint]bool, error) {
+	if lang == s.langs.Source || len(list) == 0 {
+		return nil, nil
+	}
+	ids := make([]uint, 0, len(list))
+	for _, r := range list {
+		ids = append(ids, r.ID)
+	}
+	rts, ets, err := s.repo.ForReleases(ids, lang)
+	if err != nil {
+		return nil, err
+	}
+	byRelease := make(map[uint]*models.ReleaseTranslation, len(rts))
+	for i := range rts {
+		byRelease[rts[i].ReleaseID] = &rts[i]
+	}
+	byEntry := make(map[uint]models.ChangelogEntryTranslation, len(ets))
+	for _, et := range ets {
+		byEntry[et.EntryID] = et
+	}
+
+	missing := map[uint]bool{}
+	for _, r := range list {
+		rt := byRelease[r.ID]
+		if rt == nil {
+			rt = &models.ReleaseTranslation{}
+		}
+		gap := translate(&r.ImportantNote, rt.ImportantNote, rt.ImportantNoteSource)
+		gap = translate(&r.OTAObs, rt.OTAObs, rt.OTAObsSource) || gap
+		for i := range r.Entries {
+			et := byEntry[r.Entries[i].ID]
+			gap = translate(&r.Entries[i].Observation, et.Observation, et.ObservationSource) || gap
+		}
+		if gap {
+			missing[r.ID] = true
+		}
+	}
+	return missing, nil
+}
+
+// translate aplica t sobre *orig; devolve true se faltou tradução ou se
+// ela foi feita sobre outro texto (src é o hash do original traduzido).
+func translate(orig *string, t, src string) bool {
+	if *orig == "" {
+		return false
+	}
+	if !translated(*orig, t, src) {
+		return true
+	}
+	*orig = t
+	return false
+}
+
+// translated diz se t é uma tradução atual de orig. Traduções gravadas
+// antes do hash existir (src vazio) continuam valendo.
+func translated(orig, t, src string) bool {
+	return t != "" && (src == "" || src == sourceHash(orig))
+}
+
+func sourceHash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// Translations devolve, para cada idioma de destino (e outros já
+// gravados), os textos traduzidos e o que ainda falta traduzir.
+func (s *TranslationService) Translations(id uint) ([]LanguageTranslation, error) {
+	rel, err := s.releases.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	rts, ets, err := s.repo.ListByRelease(id)
+	if err != nil {
+		return nil, err
+	}
+
+	byLang := map[string]*LanguageTranslation{}
+	var order []string
+	get := func(lang string) *LanguageTranslation {
+		if lt, ok := byLang[lang]; ok {
+			return lt
+		}
+		lt := &LanguageTranslation{Lang: lang}
+		byLang[lang] = lt
+		order = append(order, lang)
+		return lt
+	}
+	for _, lang := range s.langs.Targets() {
+		get(lang)
+	}
+	for _, rt := range rts {
+		lt := get(rt.Lang)
+		lt.ImportantNote, lt.OTAObs = rt.ImportantNote, rt.OTAObs
+		lt.importantNoteSource, lt.otaObsSource = rt.ImportantNoteSource, rt.OTAObsSource
+	}
+	for _, et := range ets {
+		lt := get(et.Lang)
+		lt.Entries = append(lt.Entries, EntryTranslation{EntryID: et.EntryID, Observation: et.Observation, source: et.ObservationSource})
+	}
+
+	out := make([]LanguageTranslation, 0, len(order))
+	for _, lang := range order {
+		lt := byLang[lang]
+		lt.Missing = translationGaps(rel, lt)
+		out = append(out, *lt)
+	}
+	return out, nil
+}
+
+func translationGaps(rel *models.Release, lt *LanguageTranslation) TranslationGaps {
+	byEntry := make(map[uint]EntryTranslation, len(lt.Entries))
+	for _, e := range lt.Entries {
+		byEntry[e.EntryID] = e
+	}
+	g := TranslationGaps{
+		ImportantNote: rel.ImportantNote != "" && !translated(rel.ImportantNote, lt.ImportantNote, lt.importantNoteSource),
+		OTAObs:        rel.OTAObs != "" && !translated(rel.OTAObs, lt.OTAObs, lt.otaObsSource),
+	}
+	for _, e := range rel.Entries {
+		et := byEntry[e.ID]
+		if e.Observation != "" && !translated(e.Observation, et.Observation, et.source) {
+			g.EntryIDs = append(g.EntryIDs, e.ID)
+		}
+	}
+	return g
+}
+
+// SaveTranslation troca todas as traduções do release no idioma in.Lang.
+// Textos vazios contam como não traduzidos.
+func (s *TranslationService) SaveTranslation(id uint, in LanguageTranslation) (*LanguageTranslation, error) {
+	lang, err := s.targetLang(in.Lang)
+	if err != nil {
+		return nil, err
+	}
+	rel, err := s.releases.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	// cada tradução guarda o hash do original que ela traduz
+	own := make(map[uint]string, len(rel.Entries))
+	for _, e := range rel.Entries {
+		own[e.ID] = sourceHash(e.Observation)
+	}
+
+	out := &LanguageTranslation{
+		Lang:                lang,
+		ImportantNote:       strings.TrimSpace(in.ImportantNote),
+		OTAObs:              strings.TrimSpace(in.OTAObs),
+		importantNoteSource: sourceHash(rel.ImportantNote),
+		otaObsSource:        sourceHash(rel.OTAObs),
+	}
+	var ets []models.ChangelogEntryTranslation
+	seen := map[uint]bool{}
+	for _, e := range in.Entries {
+		src, ok := own[e.EntryID]
+		if !ok {
+			return nil, invalidf("entrada %d não pertence a este release", e.EntryID)
+		}
+		if seen[e.EntryID] {
+			return nil, invalidf("entrada %d repetida", e.EntryID)
+		}
+		seen[e.EntryID] = true
+		obs := strings.TrimSpace(e.Observation)
+		if obs == "" {
+			continue
+		}
+		ets = append(ets, models.ChangelogEntryTranslation{EntryID: e.EntryID, Lang: lang, Observation: obs, ObservationSource: src})
+		out.Entries = append(out.Entries, EntryTranslation{EntryID: e.EntryID, Observation: obs, source: src})
+	}
+
+	rt := &models.ReleaseTranslation{
+		ReleaseID: id, Lang: lang,
+		ImportantNote: out.ImportantNote, ImportantNoteSource: out.importantNoteSource,
+		OTAObs: out.OTAObs, OTAObsSource: out.otaObsSource,
+	}
+	if err := s.repo.Replace(rt, ets); err != nil {
+		return nil, err
+	}
+	out.Missing = translationGaps(rel, out)
+	return out, nil
+}
+
+func (s *TranslationService) DeleteTranslation(id uint, lang string) error {
+	if _, err := s.releases.GetByID(id); err != nil {
+		return err
+	}
+	err := s.repo.Delete(id, i18n.Canonical(lang))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrTranslationNotFound
+	}
+	return err
+}
+
+func (s *TranslationService) targetLang(lang string) (string, error) {
+	lang = i18n.Canonical(lang)
+	for _, t := range s.langs.Targets() {
+		if t == lang {
+			return lang, nil
+		}
+	}
+	if lang == s.langs.Source {
+		return "", invalidf("%s é o idioma original; edite o próprio release", lang)
+	}
+	return "", invalidf("idioma não suportado: %q (use %s)", lang, strings.Join(s.langs.Targets(), ", "))
+}
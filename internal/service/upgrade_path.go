@@ -0,0 +1,145 @@
+// internal/service/upgrade_path.go
+package service
+
+import (
+	"fmt"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/version"
+)
+
+// Códigos de problema no caminho de atualização
+const (
+	UpgradeIssueNotFound = "not_found" // versão de destino não cadastrada
+	UpgradeIssueGap      = "gap"       // corrente PreviousVersion interrompida antes de chegar na origem
+	UpgradeIssueCycle    = "cycle"     // corrente PreviousVersion volta para um release já visitado
+	UpgradeIssueNonOTA   = "non_ota"   // passo exige atualização manual (OTA=false)
+)
+
+// UpgradeIssue descreve um problema encontrado ao resolver o caminho.
+type UpgradeIssue struct {
+	Code    string `json:"code"`
+	Version string `json:"version"`
+	Message string `json:"message"`
+}
+
+// UpgradePathError interrompe a resolução (not_found, gap, cycle).
+type UpgradePathError struct{ UpgradeIssue }
+
+func (e *UpgradePathError) Error() string { return e.Message }
+
+func upgradeErr(code, v, format string, args ...any) error {
+	return &UpgradePathError{UpgradeIssue{Code: code, Version: v, Message: fmt.Sprintf(format, args...)}}
+}
+
+// UpgradePath é a sequência de releases a aplicar, da mais antiga para a
+// de destino. Issues lista passos que não impedem o caminho, mas exigem
+// atenção do técnico (hoje, apenas saltos sem OTA).
+type UpgradePath struct {
+	Steps  []models.Release
+	Issues []UpgradeIssue
+}
+
+// UpgradePath segue os links PreviousVersion de "to" até "from" dentro de
+// um produto. "from" é a versão instalada no equipamento e não precisa
+// existir como release (ex.: versão de fábrica). Releases em revisão não
+// entram no caminho.
+func (s *ReleaseService) UpgradePath(product, from, to string) (*UpgradePath, error) {
+	if product == "" || from == "" || to == "" {
+		return nil, invalidf("product, from e to são obrigatórios")
+	}
+	vf, err := version.Parse(from)
+	if err != nil {
+		return nil, invalidf("from: %v", err)
+	}
+	vt, err := version.Parse(to)
+	if err != nil {
+		return nil, invalidf("to: %v", err)
+	}
+	if vf.Compare(vt) > 0 {
+		return nil, invalidf("from (%s) é maior que to (%s): downgrade não suportado", from, to)
+	}
+
+	list, err := s.List(ReleaseQuery{Product: product})
+	if err != nil {
+		return nil, err
+	}
+
+	out := &UpgradePath{}
+	if vf.Compare(vt) == 0 {
+		return out, nil
+	}
+	if out.Steps, err = upgradeSteps(list, product, from, to); err != nil {
+		return nil, err
+	}
+	for _, st := range out.Steps {
+		if !st.OTA {
+			out.Issues = append(out.Issues, UpgradeIssue{
+				Code:    UpgradeIssueNonOTA,
+				Version: st.Version,
+				Message: fmt.Sprintf("atualização para %s não é OTA", st.Version),
+			})
+		}
+	}
+	return out, nil
+}
+
+// upgradeSteps faz a caminhada de "to" para trás até a corrente passar por
+// "from" (PreviousVersion <= from: o passo se aplica sobre a versão
+// instalada) e devolve os passos do mais antigo para o destino.
+func upgradeSteps(list []models.Release, product, from, to string) ([]models.Release, error) {
+	published := make([]models.Release, 0, len(list))
+	for _, r := range list {
+		if r.Status != models.FirmwareStatusRevisao {
+			published = append(published, r)
+		}
+	}
+
+	cur := findVersion(published, to)
+	if cur == nil {
+		return nil, upgradeErr(UpgradeIssueNotFound, to, "versão %s não encontrada (publicada) para o produto %s", to, product)
+	}
+
+	visited := map[uint]bool{}
+	var rev []models.Release
+	for {
+		if visited[cur.ID] {
+			return nil, upgradeErr(UpgradeIssueCycle, cur.Version, "ciclo na cadeia de versões em %s", cur.Version)
+		}
+		visited[cur.ID] = true
+		rev = append(rev, *cur)
+
+		if cur.PreviousVersion == "" {
+			return nil, upgradeErr(UpgradeIssueGap, cur.Version, "release %s não informa versão anterior; cadeia não alcança %s", cur.Version, from)
+		}
+		if version.Compare(cur.PreviousVersion, from) <= 0 {
+			break
+		}
+		prev := findVersion(published, cur.PreviousVersion)
+		if prev == nil {
+			return nil, upgradeErr(UpgradeIssueGap, cur.PreviousVersion, "versão anterior %s (de %s) não cadastrada ou não publicada", cur.PreviousVersion, cur.Version)
+		}
+		cur = prev
+	}
+
+	steps := make([]models.Release, 0, len(rev))
+	for i := len(rev) - 1; i >= 0; i-- {
+		steps = append(steps, rev[i])
+	}
+	return steps, nil
+}
+
+// findVersion localiza o release com versão equivalente a v (1.2 == 1.2.0).
+func findVersion(list []models.Release, v string) *models.Release {
+	for i := range list {
+		if list[i].Version == v {
+			return &list[i]
+		}
+	}
+	for i := range list {
+		if version.Valid(list[i].Version) && version.Compare(list[i].Version, v) == 0 {
+			return &list[i]
+		}
+	}
+	return nil
+}
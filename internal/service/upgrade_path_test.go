@@ -0,0 +1,58 @@
+package service_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/models"
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/service"
+)
+
+func TestUpgradeSteps(t *testing.T) {
+	rel := func(id uint, v, prev string, st models.FirmwareStatus) models.Release {
+		return models.Release{ID: id, Version: v, PreviousVersion: prev, Status: st}
+	}
+	const prod, revisao = models.FirmwareStatusProducao, models.FirmwareStatusRevisao
+	list := []models.Release{
+		rel(1, "1.0.0", "", prod),
+		rel(2, "1.1.0", "1.0.0", prod),
+		rel(3, "1.2.0", "1.1.0", prod),
+		rel(4, "1.3.0", "1.2.0", models.FirmwareStatusDescontinuado),
+		rel(5, "1.4.0", "1.3.0", prod),
+		rel(6, "1.5.0", "1.4.0", revisao),
+		rel(7, "2.0.0", "1.5.0", prod), // depende de um release em revisão
+	}
+	for _, tc := range []struct {
+		name, from, to string
+		want           []string
+		code           string
+	}{
+		{"cadeia completa", "1.0.0", "1.2.0", []string{"1.1.0", "1.2.0"}, ""},
+		{"para na origem", "1.2.0", "1.4.0", []string{"1.3.0", "1.4.0"}, ""},
+		{"origem fora da cadeia", "1.1.5", "1.4.0", []string{"1.2.0", "1.3.0", "1.4.0"}, ""},
+		{"origem abaixo da primeira", "0.9.0", "1.1.0", nil, service.UpgradeIssueGap},
+		{"destino em revisão", "1.4.0", "1.5.0", nil, service.UpgradeIssueNotFound},
+		{"passa por release em revisão", "1.4.0", "2.0.0", nil, service.UpgradeIssueGap},
+	} {
+		steps, err := service.UpgradeSteps(list, "P", tc.from, tc.to)
+		if tc.code != "" {
+			var pe *service.UpgradePathError
+			if !errors.As(err, &pe) || pe.Code != tc.code {
+				t.Errorf("%s: err = %v, esperava %s", tc.name, err, tc.code)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tc.name, err)
+			continue
+		}
+		var got []string
+		for _, s := range steps {
+			got = append(got, s.Version)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: got %v, esperava %v", tc.name, got, tc.want)
+		}
+	}
+}
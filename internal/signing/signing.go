@@ -0,0 +1,180 @@
+// internal/signing/signing.go
+
+// Package signing verifica assinaturas de firmware feitas pelos fabricantes.
+//
+// O conteúdo assinado é o próprio arquivo (assinatura destacada, ver
+// VerifyFile) ou o payload de um manifesto assinado (ver OpenManifest e
+// Verify):
+//   - ecdsa-p256: assinatura ASN.1 (DER) sobre o SHA-256 do conteúdo, como
+//     a gerada por "openssl dgst -sha256 -sign chave.pem -out fw.sig fw.bin";
+//   - ed25519: no manifesto, Ed25519 puro sobre o payload; no arquivo,
+//     Ed25519 puro sobre os bytes (como "openssl pkeyutl -sign -rawin"),
+//     quando o arquivo cabe em memória (FileDigest.Content), ou Ed25519ph
+//     (RFC 8032, pré-hash SHA-512), que vale para qualquer tamanho.
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"strings"
+)
+
+type Algorithm string
+
+const (
+	Ed25519   Algorithm = "ed25519"
+	ECDSAP256 Algorithm = "ecdsa-p256"
+)
+
+// PublicKey é uma chave pública de fabricante já validada.
+type PublicKey struct {
+	Algorithm Algorithm
+	// ID identifica a chave: primeiros 8 bytes (hex) do SHA-256 do DER.
+	ID  string
+	der []byte
+	key any
+}
+
+// ParsePublicKey lê uma chave em PEM "PUBLIC KEY" (PKIX), ed25519 ou
+// ECDSA P-256.
+func ParsePublicKey(pemText string) (*PublicKey, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(pemText)))
+	if block == nil || block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf(`chave pública deve estar em PEM "PUBLIC KEY"`)
+	}
+	k, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("chave pública inválida: %w", err)
+	}
+	out := &PublicKey{der: block.Bytes, key: k}
+	switch k := k.(type) {
+	case ed25519.PublicKey:
+		out.Algorithm = Ed25519
+	case *ecdsa.PublicKey:
+		if k.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("curva ECDSA não suportada: %s (use P-256)", k.Curve.Params().Name)
+		}
+		out.Algorithm = ECDSAP256
+	default:
+		return nil, fmt.Errorf("tipo de chave não suportado: %T (use ed25519 ou ECDSA P-256)", k)
+	}
+	sum := sha256.Sum256(block.Bytes)
+	out.ID = hex.EncodeToString(sum[:8])
+	return out, nil
+}
+
+// PEM devolve a chave em formato canônico (sem texto extra em volta).
+func (k *PublicKey) PEM() string {
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: k.der}))
+}
+
+// Verify confere sig sobre msg, a mensagem inteira.
+func (k *PublicKey) Verify(msg, sig []byte) bool {
+	if len(sig) == 0 {
+		return false
+	}
+	switch key := k.key.(type) {
+	case ed25519.PublicKey:
+		return ed25519.Verify(key, msg, sig)
+	case *ecdsa.PublicKey:
+		digest := sha256.Sum256(msg)
+		return ecdsa.VerifyASN1(key, digest[:], sig)
+	}
+	return false
+}
+
+// FileDigest são os digests de um arquivo, calculados enquanto ele é lido.
+type FileDigest struct {
+	SHA256  []byte // para ecdsa-p256
+	SHA512  []byte // para ed25519 (Ed25519ph)
+	Content []byte // o arquivo inteiro, se coube em memória (Ed25519 puro); nil se não
+}
+
+// VerifyFile confere a assinatura destacada de um arquivo pelos digests
+// (ou, para Ed25519 puro, pelo conteúdo).
+func (k *PublicKey) VerifyFile(d FileDigest, sig []byte) bool {
+	if len(sig) == 0 {
+		return false
+	}
+	switch key := k.key.(type) {
+	case ed25519.PublicKey:
+		if d.Content != nil && ed25519.Verify(key, d.Content, sig) {
+			return true
+		}
+		if len(d.SHA512) != sha512.Size {
+			return false
+		}
+		return ed25519.VerifyWithOptions(key, d.SHA512, sig, &ed25519.Options{Hash: crypto.SHA512}) == nil
+	case *ecdsa.PublicKey:
+		if len(d.SHA256) != sha256.Size {
+			return false
+		}
+		return ecdsa.VerifyASN1(key, d.SHA256, sig)
+	}
+	return false
+}
+
+// DecodeSignature aceita a assinatura em bytes ou em base64 (texto).
+func DecodeSignature(b []byte) []byte {
+	s := strings.TrimSpace(string(b))
+	if s == "" {
+		return nil
+	}
+	if raw, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return raw
+	}
+	return b
+}
+
+// Manifest descreve o arquivo coberto por um manifesto assinado.
+type Manifest struct {
+	Filename string `json:"filename"`
+	SHA256   string `json:"sha256"` // hex
+	Size     int64  `json:"size,omitempty"`
+}
+
+// envelope do manifesto: payload é o JSON do Manifest em base64 e a
+// assinatura cobre os bytes desse JSON.
+type envelope struct {
+	Payload   string `json:"payload"`
+	Signature string `json:"signature"`
+}
+
+// OpenManifest decodifica o envelope. Devolve o manifesto, o payload
+// assinado e a assinatura; a verificação fica com quem chama (Verify).
+func OpenManifest(b []byte) (Manifest, []byte, []byte, error) {
+	var env envelope
+	if err := json.Unmarshal(b, &env); err != nil {
+		return Manifest{}, nil, nil, fmt.Errorf("manifesto inválido: %w", err)
+	}
+	payload, err := base64.StdEncoding.DecodeString(env.Payload)
+	if err != nil || len(payload) == 0 {
+		return Manifest{}, nil, nil, fmt.Errorf("manifesto inválido: payload deve ser JSON em base64")
+	}
+	sig, err := base64.StdEncoding.DecodeString(env.Signature)
+	if err != nil || len(sig) == 0 {
+		return Manifest{}, nil, nil, fmt.Errorf("manifesto inválido: signature deve estar em base64")
+	}
+	var m Manifest
+	if err := json.Unmarshal(payload, &m); err != nil {
+		return Manifest{}, nil, nil, fmt.Errorf("manifesto inválido: %w", err)
+	}
+	m.SHA256 = strings.ToLower(strings.TrimSpace(m.SHA256))
+	if len(m.SHA256) != 2*sha256.Size {
+		return Manifest{}, nil, nil, fmt.Errorf("manifesto inválido: sha256 ausente ou malformado")
+	}
+	if _, err := hex.DecodeString(m.SHA256); err != nil {
+		return Manifest{}, nil, nil, fmt.Errorf("manifesto inválido: sha256 não é hex")
+	}
+	return m, payload, sig, nil
+}
@@ -0,0 +1,172 @@
+package signing_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"testing"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/signing"
+)
+
+func pemOf(t *testing.T, pub any) string {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}))
+}
+
+func TestVerifyFile(t *testing.T) {
+	edPub, edPriv, _ := ed25519.GenerateKey(rand.Reader)
+	ecPriv, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	fw := []byte("firmware v1.2.3")
+	digests := func(b []byte) signing.FileDigest {
+		s256, s512 := sha256.Sum256(b), sha512.Sum512(b)
+		return signing.FileDigest{SHA256: s256[:], SHA512: s512[:]}
+	}
+	d := digests(fw)
+	ecSig, err := ecdsa.SignASN1(rand.Reader, ecPriv, d.SHA256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Ed25519ph: assina o SHA-512 do arquivo
+	edSig, err := edPriv.Sign(nil, d.SHA512, &ed25519.Options{Hash: crypto.SHA512})
+	if err != nil {
+		t.Fatal(err)
+	}
+	cases := []struct {
+		name string
+		pub  any
+		alg  signing.Algorithm
+		sig  []byte
+	}{
+		{"ed25519", edPub, signing.Ed25519, edSig},
+		{"ecdsa-p256", &ecPriv.PublicKey, signing.ECDSAP256, ecSig},
+	}
+	other := digests([]byte("firmware adulterado"))
+	for _, tc := range cases {
+		k, err := signing.ParsePublicKey(pemOf(t, tc.pub))
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if k.Algorithm != tc.alg || len(k.ID) != 16 {
+			t.Fatalf("%s: algoritmo %q, id %q", tc.name, k.Algorithm, k.ID)
+		}
+		if !k.VerifyFile(d, tc.sig) {
+			t.Fatalf("%s: assinatura válida recusada", tc.name)
+		}
+		// base64 também é aceito
+		b64 := []byte(base64.StdEncoding.EncodeToString(tc.sig) + "\n")
+		if !k.VerifyFile(d, signing.DecodeSignature(b64)) {
+			t.Fatalf("%s: assinatura em base64 recusada", tc.name)
+		}
+		if k.VerifyFile(other, tc.sig) {
+			t.Fatalf("%s: assinatura aceita para outro conteúdo", tc.name)
+		}
+	}
+
+	// Ed25519 puro sobre os bytes do arquivo: só com o conteúdo em memória
+	k, _ := signing.ParsePublicKey(pemOf(t, edPub))
+	pure := ed25519.Sign(edPriv, fw)
+	if k.VerifyFile(d, pure) {
+		t.Fatal("ed25519: assinatura pura aceita sem o conteúdo")
+	}
+	withContent := d
+	withContent.Content = fw
+	if !k.VerifyFile(withContent, pure) {
+		t.Fatal("ed25519: assinatura pura sobre o arquivo recusada")
+	}
+	if !k.VerifyFile(withContent, edSig) {
+		t.Fatal("ed25519: Ed25519ph recusada com o conteúdo em memória")
+	}
+	tampered := other
+	tampered.Content = []byte("firmware adulterado")
+	if k.VerifyFile(tampered, pure) {
+		t.Fatal("ed25519: assinatura pura aceita para outro conteúdo")
+	}
+	// Ed25519 puro sobre o digest não é Ed25519ph
+	if k.VerifyFile(withContent, ed25519.Sign(edPriv, d.SHA512)) {
+		t.Fatal("ed25519: assinatura sem pré-hash aceita como Ed25519ph")
+	}
+}
+
+func TestVerify(t *testing.T) {
+	edPub, edPriv, _ := ed25519.GenerateKey(rand.Reader)
+	ecPriv, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	msg := []byte(`{"filename":"fw.bin"}`)
+	digest := sha256.Sum256(msg)
+	ecSig, err := ecdsa.SignASN1(rand.Reader, ecPriv, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tc := range []struct {
+		name string
+		pub  any
+		sig  []byte
+	}{
+		{"ed25519", edPub, ed25519.Sign(edPriv, msg)},
+		{"ecdsa-p256", &ecPriv.PublicKey, ecSig},
+	} {
+		k, err := signing.ParsePublicKey(pemOf(t, tc.pub))
+		if err != nil {
+			t.Fatalf("%s: %v", tc.name, err)
+		}
+		if !k.Verify(msg, tc.sig) {
+			t.Fatalf("%s: assinatura válida recusada", tc.name)
+		}
+		if k.Verify([]byte(`{"filename":"outro.bin"}`), tc.sig) {
+			t.Fatalf("%s: assinatura aceita para outra mensagem", tc.name)
+		}
+	}
+}
+
+func TestParsePublicKey_Unsupported(t *testing.T) {
+	p384, _ := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	for _, in := range []string{"", "não é PEM", pemOf(t, &p384.PublicKey)} {
+		if _, err := signing.ParsePublicKey(in); err == nil {
+			t.Fatalf("esperava erro para %q", in)
+		}
+	}
+}
+
+func TestOpenManifest(t *testing.T) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+	k, err := signing.ParsePublicKey(pemOf(t, pub))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw := sha256.Sum256([]byte("firmware"))
+	payload, _ := json.Marshal(signing.Manifest{Filename: "fw.bin", SHA256: hex.EncodeToString(fw[:]), Size: 8})
+	env, _ := json.Marshal(map[string]string{
+		"payload":   base64.StdEncoding.EncodeToString(payload),
+		"signature": base64.StdEncoding.EncodeToString(ed25519.Sign(priv, payload)),
+	})
+
+	m, signed, sig, err := signing.OpenManifest(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Filename != "fw.bin" || m.SHA256 != hex.EncodeToString(fw[:]) || m.Size != 8 {
+		t.Fatalf("manifesto = %+v", m)
+	}
+	if !k.Verify(signed, sig) {
+		t.Fatal("manifesto assinado recusado")
+	}
+
+	if _, _, _, err := signing.OpenManifest([]byte(`{"payload":"e30=","signature":"AA=="}`)); err == nil {
+		t.Fatal("esperava erro para manifesto sem sha256")
+	}
+}
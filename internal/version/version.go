@@ -0,0 +1,219 @@
+// internal/version/version.go
+package version
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Version é a forma interpretada de uma versão de firmware.
+// Aceita tanto o esquema numérico dos módulos ("1.3033.0") quanto
+// tags no estilo semver ("v2.1.0-rc.1+build.7").
+type Version struct {
+	Raw        string
+	Core       []uint64 // segmentos numéricos: 1.3033.0 -> [1 3033 0]
+	Prerelease []string // "rc.1" -> ["rc" "1"]
+	Build      string   // ignorado na comparação
+}
+
+// Parse interpreta s. Um "v" inicial é opcional; o núcleo precisa ter ao
+// menos um segmento numérico, sem segmentos vazios.
+func Parse(s string) (Version, error) {
+	raw := strings.TrimSpace(s)
+	v := Version{Raw: raw}
+	if raw == "" {
+		return v, fmt.Errorf("versão vazia")
+	}
+
+	rest := raw
+	if rest[0] == 'v' || rest[0] == 'V' {
+		rest = rest[1:]
+	}
+	if i := strings.IndexByte(rest, '+'); i >= 0 {
+		v.Build = rest[i+1:]
+		rest = rest[:i]
+		if v.Build == "" {
+			return v, fmt.Errorf("versão inválida %q: build vazio", raw)
+		}
+	}
+	if i := strings.IndexByte(rest, '-'); i >= 0 {
+		pre := rest[i+1:]
+		rest = rest[:i]
+		if pre == "" {
+			return v, fmt.Errorf("versão inválida %q: pré-release vazio", raw)
+		}
+		for _, id := range strings.Split(pre, ".") {
+			if id == "" || !isIdent(id) {
+				return v, fmt.Errorf("versão inválida %q: pré-release %q", raw, pre)
+			}
+			v.Prerelease = append(v.Prerelease, id)
+		}
+	}
+
+	for _, seg := range strings.Split(rest, ".") {
+		if seg == "" || !isDigits(seg) {
+			return v, fmt.Errorf("versão inválida %q: segmento %q", raw, seg)
+		}
+		n, err := strconv.ParseUint(seg, 10, 64)
+		if err != nil {
+			return v, fmt.Errorf("versão inválida %q: %w", raw, err)
+		}
+		v.Core = append(v.Core, n)
+	}
+	return v, nil
+}
+
+// Valid informa se s é uma versão reconhecida.
+func Valid(s string) bool {
+	_, err := Parse(s)
+	return err == nil
+}
+
+// String devolve a forma canônica: sem o "v", com ao menos três segmentos
+// no núcleo, sem zeros à direita além deles e sem o build (1.2 -> 1.2.0,
+// v1.2.0.0 -> 1.2.0, 1.0+b7 -> 1.0.0). Versões que Compare considera
+// iguais têm exatamente a mesma forma canônica.
+func (v Version) String() string {
+	core := v.Core
+	for len(core) > 3 && core[len(core)-1] == 0 {
+		core = core[:len(core)-1]
+	}
+	var b strings.Builder
+	for i := 0; i < len(core) || i < 3; i++ {
+		if i > 0 {
+			b.WriteByte('.')
+		}
+		var n uint64
+		if i < len(core) {
+			n = core[i]
+		}
+		b.WriteString(strconv.FormatUint(n, 10))
+	}
+	for i, id := range v.Prerelease {
+		if i == 0 {
+			b.WriteByte('-')
+		} else {
+			b.WriteByte('.')
+		}
+		if isDigits(id) {
+			n, _ := strconv.ParseUint(id, 10, 64)
+			id = strconv.FormatUint(n, 10)
+		}
+		b.WriteString(id)
+	}
+	return b.String()
+}
+
+// Canonical devolve a forma canônica de s (ver String); versões inválidas
+// voltam como vieram.
+func Canonical(s string) string {
+	v, err := Parse(s)
+	if err != nil {
+		return s
+	}
+	return v.String()
+}
+
+// Compare devolve -1, 0 ou 1 conforme a < b, a == b ou a > b.
+// Segmentos ausentes valem zero (1.2 == 1.2.0) e uma pré-release vem
+// antes da versão final correspondente, como em semver.
+func (a Version) Compare(b Version) int {
+	n := len(a.Core)
+	if len(b.Core) > n {
+		n = len(b.Core)
+	}
+	for i := 0; i < n; i++ {
+		var x, y uint64
+		if i < len(a.Core) {
+			x = a.Core[i]
+		}
+		if i < len(b.Core) {
+			y = b.Core[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+
+	switch {
+	case len(a.Prerelease) == 0 && len(b.Prerelease) == 0:
+		return 0
+	case len(a.Prerelease) == 0:
+		return 1
+	case len(b.Prerelease) == 0:
+		return -1
+	}
+	for i := 0; i < len(a.Prerelease) && i < len(b.Prerelease); i++ {
+		if c := compareIdent(a.Prerelease[i], b.Prerelease[i]); c != 0 {
+			return c
+		}
+	}
+	switch {
+	case len(a.Prerelease) < len(b.Prerelease):
+		return -1
+	case len(a.Prerelease) > len(b.Prerelease):
+		return 1
+	}
+	return 0
+}
+
+// Compare interpreta e compara duas strings. Versões inválidas ficam
+// depois das válidas e, entre si, em ordem lexicográfica — assim uma
+// listagem nunca quebra por causa de um dado legado.
+func Compare(a, b string) int {
+	va, errA := Parse(a)
+	vb, errB := Parse(b)
+	switch {
+	case errA == nil && errB == nil:
+		return va.Compare(vb)
+	case errA == nil:
+		return -1
+	case errB == nil:
+		return 1
+	}
+	return strings.Compare(a, b)
+}
+
+func compareIdent(x, y string) int {
+	xn, yn := isDigits(x), isDigits(y)
+	switch {
+	case xn && yn:
+		a, _ := strconv.ParseUint(x, 10, 64)
+		b, _ := strconv.ParseUint(y, 10, 64)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	case xn:
+		return -1 // numéricos vêm antes de alfanuméricos
+	case yn:
+		return 1
+	}
+	return strings.Compare(x, y)
+}
+
+func isDigits(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func isIdent(s string) bool {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '-') {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,77 @@
+package version_test
+
+import (
+	"testing"
+
+	"github.com/DhioneCastilhoBarbosa/firmware-changelog/internal/version"
+)
+
+func TestParse_Valid(t *testing.T) {
+	for _, s := range []string{"1.3033.0", "v2.1.0", "V3", "1.0.0-rc.1", "1.0.0-beta+exp.sha.5114f85"} {
+		if _, err := version.Parse(s); err != nil {
+			t.Fatalf("%q: erro inesperado: %v", s, err)
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, s := range []string{"", "1..0", "1.O.2", "1.0-", "1.0+", "abc", "1.0.0-rc..1", ".1"} {
+		if _, err := version.Parse(s); err == nil {
+			t.Fatalf("%q: esperava erro", s)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.3033.0", "1.3033.0", 0},
+		{"1.2", "1.2.0", 0},
+		{"v1.2.0", "1.2.0", 0},
+		{"1.9.0", "1.10.0", -1},
+		{"1.3033.0", "1.304.0", 1},
+		{"1.0.0-rc.1", "1.0.0", -1},
+		{"1.0.0-rc.2", "1.0.0-rc.10", -1},
+		{"1.0.0-alpha", "1.0.0-alpha.1", -1},
+		{"1.0.0-1", "1.0.0-alpha", -1},
+		{"1.0.0+a", "1.0.0+b", 0},
+		{"2.0.0", "lixo", -1},
+		{"lixo", "2.0.0", 1},
+	}
+	for _, c := range cases {
+		if got := version.Compare(c.a, c.b); got != c.want {
+			t.Fatalf("Compare(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	cases := []struct{ in, want string }{
+		{"1.2", "1.2.0"},
+		{"1.2.0", "1.2.0"},
+		{"v1.2.0", "1.2.0"},
+		{"V3", "3.0.0"},
+		{"01.02.3", "1.2.3"},
+		{"1.3033.0", "1.3033.0"},
+		{"1.2.0.0", "1.2.0"},
+		{"1.2.3.4", "1.2.3.4"},
+		{"1.2.3.4.0", "1.2.3.4"},
+		{"1.0-rc.01", "1.0.0-rc.1"},
+		{"1.0.0+build.7", "1.0.0"},
+		{"v1.0-rc.1+b", "1.0.0-rc.1"},
+		{"lixo", "lixo"},
+	}
+	for _, tc := range cases {
+		if got := version.Canonical(tc.in); got != tc.want {
+			t.Fatalf("Canonical(%q) = %q, esperava %q", tc.in, got, tc.want)
+		}
+	}
+	// iguais para Compare => mesma forma canônica
+	for _, p := range [][2]string{{"1.2", "1.2.0"}, {"v1.2.0", "1.2"}, {"1.2.0.0", "1.2"}, {"1.0.0+a", "1.0.0+b"}} {
+		if version.Compare(p[0], p[1]) != 0 || version.Canonical(p[0]) != version.Canonical(p[1]) {
+			t.Fatalf("%q e %q deveriam ter a mesma forma canônica", p[0], p[1])
+		}
+	}
+}